@@ -1,2 +1,7 @@
 data "qlik_data_connections" "example" {
-}
\ No newline at end of file
+}
+# Every Snowflake connection in a space
+data "qlik_data_connections" "snowflake" {
+  space_id       = "65a8f1c2e4b0a1d2c3e4f5a6"
+  data_source_id = "reptgt_qdisnowflake"
+}
@@ -1,3 +1,6 @@
 data "qlik_data_gateway" "example" {
   id = "data-gateway-id"
-}
\ No newline at end of file
+}
+data "qlik_data_gateway" "east" {
+  name = "Gateway East"
+}
@@ -0,0 +1,18 @@
+# Every connected gateway, to create data connections on a healthy one
+data "qlik_data_gateways" "connected" {
+  status = "connected"
+}
+
+resource "qlik_data_connection" "example" {
+  name       = "example"
+  space_id   = "space-id"
+  gateway_id = data.qlik_data_gateways.connected.data_gateways[0].id
+  type       = "reptgt_qdisnowflake"
+  connection_parameters = {
+    server    = "acme.snowflakecomputing.com"
+    username  = "loader"
+    password  = var.snowflake_password
+    warehouse = "LOAD_WH"
+    database  = "RAW"
+  }
+}
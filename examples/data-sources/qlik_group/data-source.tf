@@ -0,0 +1,10 @@
+data "qlik_group" "analysts" {
+  name = "Analysts"
+}
+
+resource "qlik_space_assignment" "analysts" {
+  space_id    = "space-id"
+  type        = "group"
+  assignee_id = data.qlik_group.analysts.id
+  roles       = ["consumer"]
+}
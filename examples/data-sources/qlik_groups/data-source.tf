@@ -0,0 +1,4 @@
+data "qlik_groups" "sales" {
+  name_regex = "^sales-"
+  status     = "active"
+}
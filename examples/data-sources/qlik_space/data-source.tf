@@ -1,3 +1,7 @@
 data "qlik_space" "example" {
   id = "space-id"
-}
\ No newline at end of file
+}
+# Look the space up by its name, which stays the same across tenants
+data "qlik_space" "sales" {
+  name = "Sales"
+}
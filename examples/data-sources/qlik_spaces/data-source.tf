@@ -1,3 +1,11 @@
 data "qlik_spaces" "Example" {
   name = "Wildcard Prefix"
 }
+
+# The ten most recently created shared spaces whose name starts with "Sales"
+data "qlik_spaces" "sales" {
+  type        = "shared"
+  name_regex  = "^Sales"
+  sort        = "-createdAt"
+  max_results = 10
+}
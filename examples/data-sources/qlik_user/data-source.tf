@@ -0,0 +1,9 @@
+data "qlik_user" "example" {
+  email = "jane.doe@example.com"
+}
+
+resource "qlik_space" "example" {
+  name     = "Sales"
+  type     = "shared"
+  owner_id = data.qlik_user.example.id
+}
@@ -0,0 +1,4 @@
+data "qlik_users" "analysts" {
+  status       = "active"
+  email_prefix = "analyst"
+}
@@ -0,0 +1,2 @@
+# Data apps are imported by the ID of their data project and their own ID
+terraform import qlik_data_app.example 65a8f1c2e4b0a1d2c3e4f5a6/65a8f1d9e4b0a1d2c3e4f5b7
@@ -0,0 +1,3 @@
+# Source selections are imported by the ID of the data project and the ID of
+# the data app they belong to
+terraform import qlik_data_app_source_selection.example 65a8f1c2e4b0a1d2c3e4f5a6/65a8f1d9e4b0a1d2c3e4f5b7
@@ -0,0 +1,7 @@
+# Data connections can be imported by their ID
+terraform import qlik_data_connection.example 1ba1e8b2-5b0e-4f0b-9c1d-5a1c8c8e2b6f
+
+# or by the ID of their space and their name
+terraform import qlik_data_connection.example 649a6cbe52e7e7f3e5e1e6b5/example
+
+# The password is not returned by Qlik Cloud and is taken from the configuration.
@@ -4,6 +4,14 @@ resource "qlik_data_connection" "example" {
   gateway_id = "data-gateway-id"
   type       = "reptgt_qdisnowflake"
   connection_parameters = {
+    server    = "acme.snowflakecomputing.com"
+    username  = "loader"
+    password  = var.snowflake_password
+    warehouse = "LOAD_WH"
+    database  = "RAW"
   }
 
-}
\ No newline at end of file
+  # Wait for the gateway to connect, up to the create or update timeout,
+  # instead of failing while it is still starting or registering
+  wait_for_gateway = true
+}
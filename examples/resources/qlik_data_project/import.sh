@@ -0,0 +1,2 @@
+# Data projects are imported by their ID
+terraform import qlik_data_project.example 65a8f1c2e4b0a1d2c3e4f5a6
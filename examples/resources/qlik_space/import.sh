@@ -0,0 +1,5 @@
+# Spaces can be imported by their ID
+terraform import qlik_space.example 649a6cbe52e7e7f3e5e1e6b5
+
+# or by their name, which must match exactly one space
+terraform import qlik_space.example "name:Some Name"
@@ -0,0 +1,2 @@
+# Space assignments are imported by the ID of their space and their own ID
+terraform import qlik_space_assignment.example 65a8f1c2e4b0a1d2c3e4f5a6/65a8f2e1e4b0a1d2c3e4f5c8
@@ -0,0 +1,11 @@
+resource "qlik_space" "example" {
+  name = "Sales"
+  type = "shared"
+}
+
+resource "qlik_space_assignment" "analysts" {
+  space_id    = qlik_space.example.id
+  type        = "group"
+  assignee_id = "group-id"
+  roles       = ["consumer", "dataconsumer"]
+}
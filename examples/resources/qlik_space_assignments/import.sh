@@ -0,0 +1,2 @@
+# The assignments of a space are imported by the ID of the space
+terraform import qlik_space_assignments.example 65a8f1c2e4b0a1d2c3e4f5a6
@@ -0,0 +1,18 @@
+# Manages every assignment of the space. Users and groups assigned to the
+# space outside of this resource are removed on the next apply.
+resource "qlik_space_assignments" "example" {
+  space_id = qlik_space.example.id
+
+  assignments = [
+    {
+      type        = "group"
+      assignee_id = "group-id"
+      roles       = ["consumer"]
+    },
+    {
+      type        = "user"
+      assignee_id = "user-id"
+      roles       = ["contributor", "facilitator"]
+    },
+  ]
+}
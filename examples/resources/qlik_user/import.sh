@@ -0,0 +1,5 @@
+# Users can be imported by their ID
+terraform import qlik_user.contractor 65a8f1c2e4b0a1d2c3e4f5a6
+
+# or by their email
+terraform import qlik_user.contractor jane.doe@example.com
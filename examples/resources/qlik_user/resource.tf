@@ -0,0 +1,16 @@
+# Invite a contractor by email and disable them instead of deleting them
+# when they are removed from the configuration
+resource "qlik_user" "contractor" {
+  email              = "jane.doe@example.com"
+  name               = "Jane Doe"
+  assigned_roles     = ["Developer", "DataAdmin"]
+  disable_on_destroy = true
+}
+
+# Provision a user by the subject of their identity provider account
+resource "qlik_user" "service" {
+  subject        = "auth0|a08D000001BNmKTIA1"
+  name           = "Reporting service"
+  assigned_roles = ["AnalyticsAdmin"]
+  status         = "active"
+}
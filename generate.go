@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/daniepett/terraform-provider-qlik/pkg/generate"
+	"github.com/daniepett/terraform-provider-qlik/pkg/provider"
+)
+
+// stringList collects the values of a flag that can be repeated.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// runGenerate implements the generate command, which writes the configuration
+// and import blocks for existing spaces. The client is configured from the
+// same QLIK_* environment variables as the provider.
+func runGenerate(args []string) error {
+	var (
+		spaces    stringList
+		outputDir string
+		force     bool
+	)
+
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s generate --space <id> [--space <id>...] [--output <dir>] [--force]\n\n", filepath.Base(os.Args[0]))
+		fmt.Fprintln(flags.Output(), "Writes Terraform configuration and import blocks for existing spaces and their contents.")
+		fmt.Fprintln(flags.Output(), "The Qlik Cloud connection is configured through the same QLIK_* environment variables as the provider.")
+		fmt.Fprintln(flags.Output())
+		flags.PrintDefaults()
+	}
+	flags.Var(&spaces, "space", "ID of a space to generate, can be repeated")
+	flags.StringVar(&outputDir, "output", ".", "directory to write the configuration to")
+	flags.BoolVar(&force, "force", false, "overwrite existing files in the output directory")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if len(spaces) == 0 {
+		flags.Usage()
+		return errors.New("at least one --space is required")
+	}
+
+	ctx := context.Background()
+
+	client, err := provider.NewClientFromEnv(ctx)
+	if err != nil {
+		return err
+	}
+
+	files, err := generate.Generate(client, generate.Options{
+		SpaceIDs: spaces,
+		Warnings: os.Stderr,
+	})
+	if err != nil {
+		return err
+	}
+
+	return files.Write(outputDir, force)
+}
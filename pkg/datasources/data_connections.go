@@ -3,11 +3,16 @@ package datasources
 import (
 	"context"
 	"fmt"
+	"regexp"
 
 	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
-	"github.com/daniepett/qlik-cloud-client-go/models"
+	"github.com/daniepett/terraform-provider-qlik/pkg/qlik"
+	"github.com/daniepett/terraform-provider-qlik/pkg/transport"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -30,6 +35,11 @@ type DataConnectionsDataSource struct {
 // spacesDataSourceModel maps the data source schema data.
 type DataConnectionsDataSourceModel struct {
 	DataConnections []DataConnectionsModel `tfsdk:"data_connections"`
+	SpaceID         types.String           `tfsdk:"space_id"`
+	DataSourceID    types.String           `tfsdk:"data_source_id"`
+	Name            types.String           `tfsdk:"name"`
+	NameRegex       types.String           `tfsdk:"name_regex"`
+	OwnerID         types.String           `tfsdk:"owner_id"`
 }
 
 // spacesModel maps coffees schema data.
@@ -38,6 +48,11 @@ type DataConnectionsModel struct {
 	Name         types.String `tfsdk:"name"`
 	Type         types.String `tfsdk:"type"`
 	DataSourceID types.String `tfsdk:"data_source_id"`
+	SpaceID      types.String `tfsdk:"space_id"`
+	OwnerID      types.String `tfsdk:"owner_id"`
+	Qri          types.String `tfsdk:"qri"`
+	Created      types.String `tfsdk:"created"`
+	Updated      types.String `tfsdk:"updated"`
 }
 
 // Metadata returns the data source type name.
@@ -65,9 +80,42 @@ func (d *DataConnectionsDataSource) Schema(_ context.Context, _ datasource.Schem
 						"data_source_id": schema.StringAttribute{
 							Computed: true,
 						},
+						"space_id": schema.StringAttribute{
+							Computed: true,
+						},
+						"owner_id": schema.StringAttribute{
+							Computed: true,
+						},
+						"qri": schema.StringAttribute{
+							Computed: true,
+						},
+						"created": schema.StringAttribute{
+							Computed: true,
+						},
+						"updated": schema.StringAttribute{
+							Computed: true,
+						},
 					},
 				},
 			},
+			"space_id": schema.StringAttribute{
+				Optional: true,
+			},
+			"data_source_id": schema.StringAttribute{
+				Optional: true,
+			},
+			"name": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("name_regex")),
+				},
+			},
+			"name_regex": schema.StringAttribute{
+				Optional: true,
+			},
+			"owner_id": schema.StringAttribute{
+				Optional: true,
+			},
 		},
 	}
 }
@@ -77,26 +125,60 @@ func (d *DataConnectionsDataSource) Read(ctx context.Context, req datasource.Rea
 	var state DataConnectionsDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	filter := models.Filter{
-		Limit: 10,
+	var nameRegex *regexp.Regexp
+	if !state.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(state.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid Name Pattern",
+				"The name_regex value is not a valid regular expression: "+err.Error(),
+			)
+			return
+		}
 	}
-	connections, err := d.client.GetDataConnections(filter)
+
+	connections, err := qlik.ListDataConnections(transport.WithContext(ctx, d.client), qlik.DataConnectionFilter{
+		SpaceID: state.SpaceID.ValueString(),
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to Read Qlik Cloud Spaces",
+			"Unable to Read Qlik Cloud Data Connections",
 			err.Error(),
 		)
 		return
 	}
 
-	// Map response body to model
-	for _, connection := range connections.Connections {
+	// Map response body to model. Only the space is filtered on by the API,
+	// the other filters are applied to the listed connections.
+	state.DataConnections = []DataConnectionsModel{}
+	for _, connection := range connections {
+		switch {
+		case !state.DataSourceID.IsNull() && connection.DataSourceID != state.DataSourceID.ValueString():
+			continue
+		case !state.Name.IsNull() && connection.Name != state.Name.ValueString():
+			continue
+		case nameRegex != nil && !nameRegex.MatchString(connection.Name):
+			continue
+		case !state.OwnerID.IsNull() && connection.User != state.OwnerID.ValueString():
+			continue
+		}
+
 		connectionState := DataConnectionsModel{
 			ID:           types.StringValue(connection.ID),
 			Name:         types.StringValue(connection.Name),
 			Type:         types.StringValue(connection.Type),
 			DataSourceID: types.StringValue(connection.DataSourceID),
+			SpaceID:      types.StringValue(connection.SpaceID),
+			OwnerID:      types.StringValue(connection.User),
+			Qri:          types.StringValue(connection.Qri),
+			Created:      types.StringValue(connection.Created),
+			Updated:      types.StringValue(connection.Updated),
 		}
 
 		state.DataConnections = append(state.DataConnections, connectionState)
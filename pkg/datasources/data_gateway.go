@@ -3,8 +3,12 @@ package datasources
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+	"github.com/daniepett/terraform-provider-qlik/pkg/qlik"
+	"github.com/daniepett/terraform-provider-qlik/pkg/transport"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -13,8 +17,9 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ datasource.DataSource              = &DataGatewayDataSource{}
-	_ datasource.DataSourceWithConfigure = &DataGatewayDataSource{}
+	_ datasource.DataSource                     = &DataGatewayDataSource{}
+	_ datasource.DataSourceWithConfigure        = &DataGatewayDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &DataGatewayDataSource{}
 )
 
 // NewSpacesDataSource is a helper function to simplify the provider implementation.
@@ -29,11 +34,28 @@ type DataGatewayDataSource struct {
 
 // DataGatewayModel maps coffees schema data.
 type DataGatewayModel struct {
-	ID types.String `tfsdk:"id"`
-	// Name        types.String `tfsdk:"name"`
-	Type        types.String `tfsdk:"type"`
-	Description types.String `tfsdk:"description"`
-	SpaceID     types.String `tfsdk:"space_id"`
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Type          types.String `tfsdk:"type"`
+	Description   types.String `tfsdk:"description"`
+	SpaceID       types.String `tfsdk:"space_id"`
+	Version       types.String `tfsdk:"version"`
+	Status        types.String `tfsdk:"status"`
+	LastHeartbeat types.String `tfsdk:"last_heartbeat"`
+}
+
+// newDataGatewayModel maps a gateway returned by the API to the schema.
+func newDataGatewayModel(gateway qlik.DataGateway) DataGatewayModel {
+	return DataGatewayModel{
+		ID:            types.StringValue(gateway.ID),
+		Name:          types.StringValue(gateway.Name),
+		Type:          types.StringValue(gateway.Type),
+		Description:   types.StringValue(gateway.Description),
+		SpaceID:       types.StringValue(gateway.SpaceID),
+		Version:       types.StringValue(gateway.Version),
+		Status:        types.StringValue(gateway.Status()),
+		LastHeartbeat: types.StringValue(gateway.LastHeartbeat),
+	}
 }
 
 // Metadata returns the data source type name.
@@ -47,10 +69,12 @@ func (d *DataGatewayDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Optional: true,
+				Computed: true,
+			},
+			"name": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
 			},
-			// "name": schema.StringAttribute{
-			// 	Optional: true,
-			// },
 			"type": schema.StringAttribute{
 				Computed: true,
 			},
@@ -60,49 +84,93 @@ func (d *DataGatewayDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 			"space_id": schema.StringAttribute{
 				Computed: true,
 			},
+			"version": schema.StringAttribute{
+				Computed: true,
+			},
+			"status": schema.StringAttribute{
+				Computed: true,
+			},
+			"last_heartbeat": schema.StringAttribute{
+				Computed: true,
+			},
 		},
 	}
 }
 
+// ConfigValidators requires the gateway to be looked up by either its ID or
+// its name.
+func (d *DataGatewayDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
 // Read refreshes the Terraform state with the latest data.
 func (d *DataGatewayDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var state DataGatewayModel
-
-	// var DataGatewayName string
-	var DataGatewayID string
-	// var DataGateway *models.DataGateway
-	var err error
-	// req.Config.GetAttribute(ctx, path.Root("name"), &DataGatewayName)
-	req.Config.GetAttribute(ctx, path.Root("id"), &DataGatewayID)
-	// DataGatewayID := state.ID.ValueString()
-
-	// if DataGatewayName != "" {
-	// 	DataGateway, err = d.client.GetDataGatewayByName(DataGatewayName)
-	// } else if DataGatewayID != "" {
-	// 	DataGateway, err = d.client.GetDataGateway(DataGatewayID)
-	// } else {
-	// 	resp.Diagnostics.AddError(
-	// 		"Both name and ID missing",
-	// 		err.Error(),
-	// 	)
-	// 	return
-	// }
-
-	dg, err := d.client.GetDataGateway(DataGatewayID)
-
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Read Qlik Cloud DataGateway",
-			err.Error(),
-		)
+	var config DataGatewayModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	state.ID = types.StringValue(dg.ID)
-	// state.Name = types.StringValue(dg.Name)
-	state.Type = types.StringValue(dg.Type)
-	state.Description = types.StringValue(dg.Description)
-	state.SpaceID = types.StringValue(dg.SpaceID)
+	client := transport.WithContext(ctx, d.client)
+
+	var gateway *qlik.DataGateway
+	if !config.ID.IsNull() {
+		var err error
+		gateway, err = qlik.GetDataGateway(client, config.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Qlik Cloud DataGateway",
+				err.Error(),
+			)
+			return
+		}
+	} else {
+		name := config.Name.ValueString()
+
+		gateways, err := qlik.ListDataGateways(client)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Qlik Cloud DataGateway",
+				err.Error(),
+			)
+			return
+		}
+
+		var matches []qlik.DataGateway
+		var ids []string
+		for _, g := range gateways {
+			if g.Name == name {
+				matches = append(matches, g)
+				ids = append(ids, g.ID)
+			}
+		}
+
+		if len(matches) == 0 {
+			resp.Diagnostics.AddError(
+				"Unable to Read Qlik Cloud DataGateway",
+				fmt.Sprintf("No data gateway named %q was found.", name),
+			)
+			return
+		}
+
+		if len(matches) > 1 {
+			resp.Diagnostics.AddError(
+				"Unable to Read Qlik Cloud DataGateway",
+				fmt.Sprintf("Found %d data gateways named %q (%s), look the gateway up by its ID instead.", len(matches), name, strings.Join(ids, ", ")),
+			)
+			return
+		}
+
+		gateway = &matches[0]
+	}
+
+	state := newDataGatewayModel(*gateway)
 
 	// Set state
 	diags := resp.State.Set(ctx, &state)
@@ -0,0 +1,149 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+	"github.com/daniepett/terraform-provider-qlik/pkg/qlik"
+	"github.com/daniepett/terraform-provider-qlik/pkg/transport"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &DataGatewaysDataSource{}
+	_ datasource.DataSourceWithConfigure = &DataGatewaysDataSource{}
+)
+
+// NewDataGatewaysDataSource is a helper function to simplify the provider implementation.
+func NewDataGatewaysDataSource() datasource.DataSource {
+	return &DataGatewaysDataSource{}
+}
+
+// DataGatewaysDataSource is the data source implementation.
+type DataGatewaysDataSource struct {
+	client *qlikcloud.Client
+}
+
+// DataGatewaysDataSourceModel maps the data source schema data.
+type DataGatewaysDataSourceModel struct {
+	DataGateways []DataGatewayModel `tfsdk:"data_gateways"`
+	SpaceID      types.String       `tfsdk:"space_id"`
+	Status       types.String       `tfsdk:"status"`
+}
+
+// Metadata returns the data source type name.
+func (d *DataGatewaysDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_data_gateways"
+}
+
+// Schema defines the schema for the data source.
+func (d *DataGatewaysDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"data_gateways": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"type": schema.StringAttribute{
+							Computed: true,
+						},
+						"description": schema.StringAttribute{
+							Computed: true,
+						},
+						"space_id": schema.StringAttribute{
+							Computed: true,
+						},
+						"version": schema.StringAttribute{
+							Computed: true,
+						},
+						"status": schema.StringAttribute{
+							Computed: true,
+						},
+						"last_heartbeat": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+			"space_id": schema.StringAttribute{
+				Optional: true,
+			},
+			"status": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("connected", "disconnected"),
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *DataGatewaysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state DataGatewaysDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	gateways, err := qlik.ListDataGateways(transport.WithContext(ctx, d.client))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Qlik Cloud DataGateways",
+			err.Error(),
+		)
+		return
+	}
+
+	// Map response body to model
+	state.DataGateways = []DataGatewayModel{}
+	for _, gateway := range gateways {
+		switch {
+		case !state.SpaceID.IsNull() && gateway.SpaceID != state.SpaceID.ValueString():
+			continue
+		case !state.Status.IsNull() && gateway.Status() != state.Status.ValueString():
+			continue
+		}
+
+		state.DataGateways = append(state.DataGateways, newDataGatewayModel(gateway))
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *DataGatewaysDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*qlikcloud.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *qlikcloud.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
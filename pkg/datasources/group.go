@@ -0,0 +1,169 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+	"github.com/daniepett/terraform-provider-qlik/pkg/qlik"
+	"github.com/daniepett/terraform-provider-qlik/pkg/transport"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &GroupDataSource{}
+	_ datasource.DataSourceWithConfigure        = &GroupDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &GroupDataSource{}
+)
+
+// NewGroupDataSource is a helper function to simplify the provider implementation.
+func NewGroupDataSource() datasource.DataSource {
+	return &GroupDataSource{}
+}
+
+// GroupDataSource is the data source implementation.
+type GroupDataSource struct {
+	client *qlikcloud.Client
+}
+
+// GroupModel maps group schema data.
+type GroupModel struct {
+	ID     types.String   `tfsdk:"id"`
+	Name   types.String   `tfsdk:"name"`
+	Status types.String   `tfsdk:"status"`
+	Roles  []types.String `tfsdk:"roles"`
+}
+
+// newGroupModel maps a group returned by the API to the schema.
+func newGroupModel(group qlik.Group) GroupModel {
+	model := GroupModel{
+		ID:     types.StringValue(group.ID),
+		Name:   types.StringValue(group.Name),
+		Status: types.StringValue(group.Status),
+		Roles:  []types.String{},
+	}
+
+	for _, role := range group.AssignedRoles {
+		model.Roles = append(model.Roles, types.StringValue(role.Name))
+	}
+
+	return model
+}
+
+// Metadata returns the data source type name.
+func (d *GroupDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group"
+}
+
+// Schema defines the schema for the data source.
+func (d *GroupDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"name": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"status": schema.StringAttribute{
+				Computed: true,
+			},
+			"roles": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// ConfigValidators requires the group to be looked up by either its ID or
+// its name.
+func (d *GroupDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *GroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config GroupModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := transport.WithContext(ctx, d.client)
+
+	var group *qlik.Group
+	if !config.ID.IsNull() {
+		var err error
+		group, err = qlik.GetGroup(client, config.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Qlik Cloud Group",
+				err.Error(),
+			)
+			return
+		}
+	} else {
+		groups, err := qlik.ListGroups(client, qlik.GroupFilter{
+			Name: config.Name.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Qlik Cloud Group",
+				err.Error(),
+			)
+			return
+		}
+
+		if len(groups) != 1 {
+			resp.Diagnostics.AddError(
+				"Unable to Read Qlik Cloud Group",
+				fmt.Sprintf("Found %d groups named %q, expected exactly one.", len(groups), config.Name.ValueString()),
+			)
+			return
+		}
+
+		group = &groups[0]
+	}
+
+	state := newGroupModel(*group)
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *GroupDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*qlikcloud.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *qlikcloud.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,150 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+	"github.com/daniepett/terraform-provider-qlik/pkg/qlik"
+	"github.com/daniepett/terraform-provider-qlik/pkg/transport"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &GroupsDataSource{}
+	_ datasource.DataSourceWithConfigure = &GroupsDataSource{}
+)
+
+// NewGroupsDataSource is a helper function to simplify the provider implementation.
+func NewGroupsDataSource() datasource.DataSource {
+	return &GroupsDataSource{}
+}
+
+// GroupsDataSource is the data source implementation.
+type GroupsDataSource struct {
+	client *qlikcloud.Client
+}
+
+// GroupsDataSourceModel maps the data source schema data.
+type GroupsDataSourceModel struct {
+	Groups    []GroupModel `tfsdk:"groups"`
+	NameRegex types.String `tfsdk:"name_regex"`
+	Status    types.String `tfsdk:"status"`
+}
+
+// Metadata returns the data source type name.
+func (d *GroupsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_groups"
+}
+
+// Schema defines the schema for the data source.
+func (d *GroupsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"groups": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"status": schema.StringAttribute{
+							Computed: true,
+						},
+						"roles": schema.ListAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+			"name_regex": schema.StringAttribute{
+				Optional: true,
+			},
+			"status": schema.StringAttribute{
+				Optional: true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *GroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state GroupsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The API cannot match names against a pattern, so the groups are
+	// filtered once they are listed.
+	var nameRegex *regexp.Regexp
+	if !state.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(state.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid Name Pattern",
+				"The name_regex value is not a valid regular expression: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	groups, err := qlik.ListGroups(transport.WithContext(ctx, d.client), qlik.GroupFilter{
+		Status: state.Status.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Qlik Cloud Groups",
+			err.Error(),
+		)
+		return
+	}
+
+	// Map response body to model
+	state.Groups = []GroupModel{}
+	for _, group := range groups {
+		if nameRegex != nil && !nameRegex.MatchString(group.Name) {
+			continue
+		}
+
+		state.Groups = append(state.Groups, newGroupModel(group))
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *GroupsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*qlikcloud.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *qlikcloud.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
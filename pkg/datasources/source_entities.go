@@ -6,6 +6,7 @@ import (
 
 	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
 	"github.com/daniepett/qlik-cloud-client-go/models"
+	"github.com/daniepett/terraform-provider-qlik/pkg/transport"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -134,7 +135,7 @@ func (d *SourceEntitiesDataSource) Read(ctx context.Context, req datasource.Read
 		},
 	}
 
-	s, err := d.client.GetSourceEntities(state.ProjectID.ValueString(), state.AppID.ValueString(), ent)
+	s, err := transport.WithContext(ctx, d.client).GetSourceEntities(state.ProjectID.ValueString(), state.AppID.ValueString(), ent)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Qlik Cloud Spaces",
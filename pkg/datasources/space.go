@@ -3,17 +3,23 @@ package datasources
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+	"github.com/daniepett/terraform-provider-qlik/pkg/qlik"
+	"github.com/daniepett/terraform-provider-qlik/pkg/transport"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ datasource.DataSource              = &SpaceDataSource{}
-	_ datasource.DataSourceWithConfigure = &SpaceDataSource{}
+	_ datasource.DataSource                     = &SpaceDataSource{}
+	_ datasource.DataSourceWithConfigure        = &SpaceDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &SpaceDataSource{}
 )
 
 // NewSpacesDataSource is a helper function to simplify the provider implementation.
@@ -32,6 +38,9 @@ type SpaceModel struct {
 	Name        types.String `tfsdk:"name"`
 	Type        types.String `tfsdk:"type"`
 	Description types.String `tfsdk:"description"`
+	OwnerID     types.String `tfsdk:"owner_id"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+	UpdatedAt   types.String `tfsdk:"updated_at"`
 }
 
 // Metadata returns the data source type name.
@@ -45,8 +54,10 @@ func (d *SpaceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Optional: true,
+				Computed: true,
 			},
 			"name": schema.StringAttribute{
+				Optional: true,
 				Computed: true,
 			},
 			"type": schema.StringAttribute{
@@ -55,30 +66,100 @@ func (d *SpaceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 			"description": schema.StringAttribute{
 				Computed: true,
 			},
+			"owner_id": schema.StringAttribute{
+				Computed: true,
+			},
+			"created_at": schema.StringAttribute{
+				Computed: true,
+			},
+			"updated_at": schema.StringAttribute{
+				Computed: true,
+			},
 		},
 	}
 }
 
+// ConfigValidators requires the space to be looked up by either its ID or
+// its name.
+func (d *SpaceDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
 // Read refreshes the Terraform state with the latest data.
 func (d *SpaceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state SpaceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
-
-	Space, err := d.client.GetSpace(state.ID.ValueString())
-
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Read Qlik Cloud Space",
-			err.Error(),
-		)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	state.ID = types.StringValue(Space.ID)
-	state.Name = types.StringValue(Space.Name)
-	state.Type = types.StringValue(Space.Type)
-	state.Description = types.StringValue(Space.Description)
+	client := transport.WithContext(ctx, d.client)
+
+	var space *qlik.Space
+	if !state.ID.IsNull() {
+		var err error
+		space, err = qlik.GetSpace(client, state.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Qlik Cloud Space",
+				err.Error(),
+			)
+			return
+		}
+	} else {
+		name := state.Name.ValueString()
+
+		spaces, err := qlik.ListSpaces(client, qlik.SpaceFilter{Name: name}, 0)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Qlik Cloud Space",
+				err.Error(),
+			)
+			return
+		}
+
+		// The name filter of the spaces API also matches partial names.
+		var matches []qlik.Space
+		var ids []string
+		for _, s := range spaces {
+			if s.Name == name {
+				matches = append(matches, s)
+				ids = append(ids, s.ID)
+			}
+		}
+
+		if len(matches) == 0 {
+			resp.Diagnostics.AddError(
+				"Unable to Read Qlik Cloud Space",
+				fmt.Sprintf("No space named %q was found.", name),
+			)
+			return
+		}
+
+		if len(matches) > 1 {
+			resp.Diagnostics.AddError(
+				"Unable to Read Qlik Cloud Space",
+				fmt.Sprintf("Found %d spaces named %q (%s), look the space up by its ID instead.", len(matches), name, strings.Join(ids, ", ")),
+			)
+			return
+		}
+
+		space = &matches[0]
+	}
+
+	state.ID = types.StringValue(space.ID)
+	state.Name = types.StringValue(space.Name)
+	state.Type = types.StringValue(space.Type)
+	state.Description = types.StringValue(space.Description)
+	state.OwnerID = types.StringValue(space.OwnerID)
+	state.CreatedAt = types.StringValue(space.CreatedAt)
+	state.UpdatedAt = types.StringValue(space.UpdatedAt)
 
 	// Set state
 	diags := resp.State.Set(ctx, &state)
@@ -3,11 +3,17 @@ package datasources
 import (
 	"context"
 	"fmt"
+	"regexp"
 
 	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
-	"github.com/daniepett/qlik-cloud-client-go/models"
+	"github.com/daniepett/terraform-provider-qlik/pkg/qlik"
+	"github.com/daniepett/terraform-provider-qlik/pkg/transport"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -17,6 +23,10 @@ var (
 	_ datasource.DataSourceWithConfigure = &SpacesDataSource{}
 )
 
+// spaceSortPattern matches the fields spaces can be sorted by, optionally
+// prefixed with + or - for ascending or descending order.
+var spaceSortPattern = regexp.MustCompile(`^[+-]?(name|createdAt|updatedAt)$`)
+
 // NewSpacesDataSource is a helper function to simplify the provider implementation.
 func NewSpacesDataSource() datasource.DataSource {
 	return &SpacesDataSource{}
@@ -29,8 +39,13 @@ type SpacesDataSource struct {
 
 // SpacesDataSourceModel maps the data source schema data.
 type SpacesDataSourceModel struct {
-	Spaces []SpacesModel `tfsdk:"spaces"`
-	Name   types.String  `tfsdk:"name"`
+	Spaces     []SpacesModel `tfsdk:"spaces"`
+	Name       types.String  `tfsdk:"name"`
+	NameRegex  types.String  `tfsdk:"name_regex"`
+	Type       types.String  `tfsdk:"type"`
+	OwnerID    types.String  `tfsdk:"owner_id"`
+	Sort       types.String  `tfsdk:"sort"`
+	MaxResults types.Int64   `tfsdk:"max_results"`
 }
 
 // SpacesModel maps coffees schema data.
@@ -39,6 +54,9 @@ type SpacesModel struct {
 	Name        types.String `tfsdk:"name"`
 	Type        types.String `tfsdk:"type"`
 	Description types.String `tfsdk:"description"`
+	OwnerID     types.String `tfsdk:"owner_id"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+	UpdatedAt   types.String `tfsdk:"updated_at"`
 }
 
 // Metadata returns the data source type name.
@@ -66,12 +84,45 @@ func (d *SpacesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 						"description": schema.StringAttribute{
 							Computed: true,
 						},
+						"owner_id": schema.StringAttribute{
+							Computed: true,
+						},
+						"created_at": schema.StringAttribute{
+							Computed: true,
+						},
+						"updated_at": schema.StringAttribute{
+							Computed: true,
+						},
 					},
 				},
 			},
 			"name": schema.StringAttribute{
 				Optional: true,
 			},
+			"name_regex": schema.StringAttribute{
+				Optional: true,
+			},
+			"type": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("shared", "managed", "data"),
+				},
+			},
+			"owner_id": schema.StringAttribute{
+				Optional: true,
+			},
+			"sort": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(spaceSortPattern, "must be name, createdAt or updatedAt, optionally prefixed with + or -"),
+				},
+			},
+			"max_results": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 		},
 	}
 }
@@ -81,12 +132,40 @@ func (d *SpacesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	var state SpacesDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	filter := models.Filter{
-		Name:  state.Name.ValueString(),
-		Limit: 10,
+	// The API cannot match names against a pattern, so the spaces are
+	// filtered once they are listed.
+	var nameRegex *regexp.Regexp
+	if !state.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(state.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid Name Pattern",
+				"The name_regex value is not a valid regular expression: "+err.Error(),
+			)
+			return
+		}
 	}
-	Spaces, err := d.client.GetSpaces(filter)
+
+	// Without a pattern the API stops listing at max_results, otherwise
+	// every space is listed so that enough of them remain after filtering.
+	maxResults := int(state.MaxResults.ValueInt64())
+	listLimit := maxResults
+	if nameRegex != nil {
+		listLimit = 0
+	}
+
+	spaces, err := qlik.ListSpaces(transport.WithContext(ctx, d.client), qlik.SpaceFilter{
+		Name:    state.Name.ValueString(),
+		Type:    state.Type.ValueString(),
+		OwnerID: state.OwnerID.ValueString(),
+		Sort:    state.Sort.ValueString(),
+	}, listLimit)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Qlik Cloud Spaces",
@@ -96,12 +175,24 @@ func (d *SpacesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 
 	// Map response body to model
-	for _, space := range Spaces.Spaces {
+	state.Spaces = []SpacesModel{}
+	for _, space := range spaces {
+		if nameRegex != nil && !nameRegex.MatchString(space.Name) {
+			continue
+		}
+
+		if maxResults > 0 && len(state.Spaces) == maxResults {
+			break
+		}
+
 		spaceState := SpacesModel{
 			ID:          types.StringValue(space.ID),
 			Name:        types.StringValue(space.Name),
 			Type:        types.StringValue(space.Type),
 			Description: types.StringValue(space.Description),
+			OwnerID:     types.StringValue(space.OwnerID),
+			CreatedAt:   types.StringValue(space.CreatedAt),
+			UpdatedAt:   types.StringValue(space.UpdatedAt),
 		}
 
 		state.Spaces = append(state.Spaces, spaceState)
@@ -0,0 +1,213 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+	"github.com/daniepett/terraform-provider-qlik/pkg/qlik"
+	"github.com/daniepett/terraform-provider-qlik/pkg/transport"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &UserDataSource{}
+	_ datasource.DataSourceWithConfigure        = &UserDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &UserDataSource{}
+)
+
+// NewUserDataSource is a helper function to simplify the provider implementation.
+func NewUserDataSource() datasource.DataSource {
+	return &UserDataSource{}
+}
+
+// UserDataSource is the data source implementation.
+type UserDataSource struct {
+	client *qlikcloud.Client
+}
+
+// UserModel maps user schema data.
+type UserModel struct {
+	ID      types.String     `tfsdk:"id"`
+	Subject types.String     `tfsdk:"subject"`
+	Name    types.String     `tfsdk:"name"`
+	Email   types.String     `tfsdk:"email"`
+	Status  types.String     `tfsdk:"status"`
+	Roles   []types.String   `tfsdk:"roles"`
+	Groups  []UserGroupModel `tfsdk:"groups"`
+}
+
+// UserGroupModel maps a group a user is assigned to.
+type UserGroupModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+// newUserModel maps a user returned by the API to the schema.
+func newUserModel(user qlik.User) UserModel {
+	model := UserModel{
+		ID:      types.StringValue(user.ID),
+		Subject: types.StringValue(user.Subject),
+		Name:    types.StringValue(user.Name),
+		Email:   types.StringValue(user.Email),
+		Status:  types.StringValue(user.Status),
+		Roles:   []types.String{},
+		Groups:  []UserGroupModel{},
+	}
+
+	for _, role := range user.AssignedRoles {
+		model.Roles = append(model.Roles, types.StringValue(role.Name))
+	}
+
+	for _, group := range user.AssignedGroups {
+		model.Groups = append(model.Groups, UserGroupModel{
+			ID:   types.StringValue(group.ID),
+			Name: types.StringValue(group.Name),
+		})
+	}
+
+	return model
+}
+
+// userGroupsAttribute defines the groups a user is assigned to.
+var userGroupsAttribute = schema.ListNestedAttribute{
+	Computed: true,
+	NestedObject: schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"name": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	},
+}
+
+// Metadata returns the data source type name.
+func (d *UserDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+// Schema defines the schema for the data source.
+func (d *UserDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"email": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"subject": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"name": schema.StringAttribute{
+				Computed: true,
+			},
+			"status": schema.StringAttribute{
+				Computed: true,
+			},
+			"roles": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"groups": userGroupsAttribute,
+		},
+	}
+}
+
+// ConfigValidators requires the user to be looked up by exactly one of its
+// identifiers.
+func (d *UserDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("email"),
+			path.MatchRoot("subject"),
+		),
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config UserModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := transport.WithContext(ctx, d.client)
+
+	var user *qlik.User
+	if !config.ID.IsNull() {
+		var err error
+		user, err = qlik.GetUser(client, config.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Qlik Cloud User",
+				err.Error(),
+			)
+			return
+		}
+	} else {
+		users, err := qlik.ListUsers(client, qlik.UserFilter{
+			Email:   config.Email.ValueString(),
+			Subject: config.Subject.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Qlik Cloud User",
+				err.Error(),
+			)
+			return
+		}
+
+		if len(users) != 1 {
+			resp.Diagnostics.AddError(
+				"Unable to Read Qlik Cloud User",
+				fmt.Sprintf("Found %d users matching the email or subject, expected exactly one.", len(users)),
+			)
+			return
+		}
+
+		user = &users[0]
+	}
+
+	state := newUserModel(*user)
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *UserDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*qlikcloud.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *qlikcloud.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
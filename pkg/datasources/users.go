@@ -0,0 +1,141 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+	"github.com/daniepett/terraform-provider-qlik/pkg/qlik"
+	"github.com/daniepett/terraform-provider-qlik/pkg/transport"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &UsersDataSource{}
+	_ datasource.DataSourceWithConfigure = &UsersDataSource{}
+)
+
+// NewUsersDataSource is a helper function to simplify the provider implementation.
+func NewUsersDataSource() datasource.DataSource {
+	return &UsersDataSource{}
+}
+
+// UsersDataSource is the data source implementation.
+type UsersDataSource struct {
+	client *qlikcloud.Client
+}
+
+// UsersDataSourceModel maps the data source schema data.
+type UsersDataSourceModel struct {
+	Users       []UserModel  `tfsdk:"users"`
+	Status      types.String `tfsdk:"status"`
+	Role        types.String `tfsdk:"role"`
+	EmailPrefix types.String `tfsdk:"email_prefix"`
+}
+
+// Metadata returns the data source type name.
+func (d *UsersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+// Schema defines the schema for the data source.
+func (d *UsersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"users": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"subject": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"email": schema.StringAttribute{
+							Computed: true,
+						},
+						"status": schema.StringAttribute{
+							Computed: true,
+						},
+						"roles": schema.ListAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"groups": userGroupsAttribute,
+					},
+				},
+			},
+			"status": schema.StringAttribute{
+				Optional: true,
+			},
+			"role": schema.StringAttribute{
+				Optional: true,
+			},
+			"email_prefix": schema.StringAttribute{
+				Optional: true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *UsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state UsersDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	users, err := qlik.ListUsers(transport.WithContext(ctx, d.client), qlik.UserFilter{
+		Status:      state.Status.ValueString(),
+		Role:        state.Role.ValueString(),
+		EmailPrefix: state.EmailPrefix.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Qlik Cloud Users",
+			err.Error(),
+		)
+		return
+	}
+
+	// Map response body to model
+	state.Users = []UserModel{}
+	for _, user := range users {
+		state.Users = append(state.Users, newUserModel(user))
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *UsersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*qlikcloud.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *qlikcloud.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,171 @@
+// Package generate writes Terraform configuration for objects that already
+// exist in a Qlik Cloud tenant, together with Terraform 1.5 import blocks, so
+// they can be brought under management without writing the configuration by
+// hand.
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+	"github.com/daniepett/qlik-cloud-client-go/models"
+	"github.com/daniepett/terraform-provider-qlik/pkg/qlik"
+)
+
+// Options configures Generate.
+type Options struct {
+	// SpaceIDs are the IDs of the spaces whose objects are generated.
+	SpaceIDs []string
+
+	// Warnings receives a line for every object that is left out, such as
+	// the source selection of a data app that has none. Nil discards them.
+	Warnings io.Writer
+}
+
+// Files holds generated configuration keyed by file name.
+type Files map[string][]byte
+
+// space is a space and the objects in it.
+type space struct {
+	models.Space
+	Connections []models.GetConnectionResponse
+	Projects    []project
+}
+
+// project is a data project and its data apps.
+type project struct {
+	models.DataProjectResponse
+	Apps []app
+}
+
+// app is a data app and its source selection, if it has one.
+type app struct {
+	models.DataApp
+	Selection *models.SourceSelectionResponse
+}
+
+// Generate reads the spaces in opts, along with their data connections, data
+// projects, data apps and source selections, and returns their configuration.
+func Generate(client *qlikcloud.Client, opts Options) (Files, error) {
+	if len(opts.SpaceIDs) == 0 {
+		return nil, errors.New("at least one space is required")
+	}
+
+	warnings := opts.Warnings
+	if warnings == nil {
+		warnings = io.Discard
+	}
+
+	var spaces []space
+	for _, id := range opts.SpaceIDs {
+		s, err := fetchSpace(client, id, warnings)
+		if err != nil {
+			return nil, err
+		}
+
+		spaces = append(spaces, s)
+	}
+
+	return render(spaces), nil
+}
+
+// fetchSpace reads a space and everything in it. Objects are sorted by name,
+// so generating the same tenant twice gives the same configuration.
+func fetchSpace(client *qlikcloud.Client, id string, warnings io.Writer) (space, error) {
+	s, err := client.GetSpace(id)
+	if err != nil {
+		return space{}, fmt.Errorf("reading space %s: %w", id, err)
+	}
+
+	connections, err := qlik.ListDataConnections(client, qlik.DataConnectionFilter{SpaceID: id})
+	if err != nil {
+		return space{}, fmt.Errorf("listing data connections in space %s: %w", id, err)
+	}
+
+	sort.SliceStable(connections, func(i, j int) bool {
+		return connections[i].Name < connections[j].Name
+	})
+
+	dataProjects, err := qlik.ListDataProjects(client, id)
+	if err != nil {
+		return space{}, fmt.Errorf("listing data projects in space %s: %w", id, err)
+	}
+
+	sort.SliceStable(dataProjects, func(i, j int) bool {
+		return dataProjects[i].DataProject.Name < dataProjects[j].DataProject.Name
+	})
+
+	result := space{Space: *s, Connections: connections}
+	for _, dataProject := range dataProjects {
+		p := project{DataProjectResponse: dataProject}
+
+		apps, err := client.GetDataApps(dataProject.DataProject.ID)
+		if err != nil {
+			return space{}, fmt.Errorf("listing data apps in data project %s: %w", dataProject.DataProject.ID, err)
+		}
+
+		sort.SliceStable(apps.DataApps, func(i, j int) bool {
+			return apps.DataApps[i].Name < apps.DataApps[j].Name
+		})
+
+		for _, dataApp := range apps.DataApps {
+			a := app{DataApp: dataApp}
+
+			// Data apps that have not been set up yet have no source
+			// selection, so it is skipped rather than failing the run.
+			selection, err := client.GetSourceSelection(dataProject.DataProject.ID, dataApp.ID)
+			switch {
+			case qlik.IsNotFound(err):
+				fmt.Fprintf(warnings, "Skipping the source selection of data app %s, which has none\n", dataApp.ID)
+			case err != nil:
+				return space{}, fmt.Errorf("reading the source selection of data app %s: %w", dataApp.ID, err)
+			default:
+				a.Selection = selection
+			}
+
+			p.Apps = append(p.Apps, a)
+		}
+
+		result.Projects = append(result.Projects, p)
+	}
+
+	return result, nil
+}
+
+// Write writes the files to dir, creating it if needed. Existing files are
+// only replaced when overwrite is set.
+func (f Files) Write(dir string, overwrite bool) error {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if !overwrite {
+		for _, name := range names {
+			p := filepath.Join(dir, name)
+			if _, err := os.Stat(p); err == nil {
+				return fmt.Errorf("%s already exists, use -force to overwrite it", p)
+			}
+		}
+	}
+
+	for _, name := range names {
+		err := os.WriteFile(filepath.Join(dir, name), f[name], 0o644)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package generate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+)
+
+func TestGenerate(t *testing.T) {
+	responses := map[string]string{
+		"/api/v1/spaces/space-1": `{"id":"space-1","name":"Sales Data","type":"data","description":"Sales"}`,
+		"/api/v1/data-connections": `{"data":[
+			{"qID":"conn-1","qName":"Snowflake","space":"space-1","datasourceID":"reptgt_qdisnowflake",
+			 "qConnectStatement":"CUSTOM CONNECT TO \"provider=QlikConnectorsCommonService.exe;sourceType=reptgt_qdisnowflake;agentId=gw-1;server=acme.snowflakecomputing.com;username=loader;metadataschema=META;\""},
+			{"qID":"conn-2","qName":"Legacy","space":"space-1","datasourceID":"reptgt_qdisnowflake",
+			 "qConnectStatement":"CUSTOM CONNECT TO \"provider=QlikConnectorsCommonService.exe;sourceType=reptgt_qdisnowflake;server=legacy.snowflakecomputing.com;\""}
+		],"links":{}}`,
+		"/api/v1/data-projects":                     `{"dataProjects":[{"id":"project-1","info":{"spaceId":"space-1"}},{"id":"project-2","info":{"spaceId":"space-2"}}]}`,
+		"/api/v1/data-projects/project-1":           `{"key":"project-1","info":{"spaceId":"space-1"},"dataProject":{"id":"project-1","name":"Pipeline","lakehouseType":"SNOWFLAKE","type":"DATA_PIPELINE","storageConnection":"conn-1"}}`,
+		"/api/v1/data-projects/project-1/data-apps": `{"dataApps":[{"id":"app-2","name":"Orders","type":"LANDING"},{"id":"app-1","name":"Orders","type":"STORAGE","description":"Stored"}]}`,
+		"/api/v1/data-projects/project-1/data-apps/app-1/source-selection": `{"key":"selection-1","sourceSelection":{"dataEntitiesSelection":{"sourceConnectionId":"external","dataEntities":[
+			{"entityID":"e1","name":"ORDERS","dataAppId":"app-1","schema":"PUBLIC","database":"DB","type":"TABLE","projectId":"project-1"}
+		]}}}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := &qlikcloud.Client{HostURL: server.URL, HTTPClient: server.Client()}
+
+	files, err := Generate(client, Options{SpaceIDs: []string{"space-1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Files{
+		"resources.tf": []byte(`resource "qlik_space" "sales_data" {
+  name        = "Sales Data"
+  type        = "data"
+  description = "Sales"
+}
+
+resource "qlik_data_connection" "legacy" {
+  name       = "Legacy"
+  space_id   = qlik_space.sales_data.id
+  type       = "reptgt_qdisnowflake"
+  gateway_id = var.legacy_gateway_id
+  connection_parameters = {
+    server   = "legacy.snowflakecomputing.com"
+    password = var.legacy_password
+  }
+}
+
+resource "qlik_data_connection" "snowflake" {
+  name       = "Snowflake"
+  space_id   = qlik_space.sales_data.id
+  type       = "reptgt_qdisnowflake"
+  gateway_id = "gw-1"
+  connection_parameters = {
+    server          = "acme.snowflakecomputing.com"
+    username        = "loader"
+    metadata_schema = "META"
+    password        = var.snowflake_password
+  }
+}
+
+resource "qlik_data_project" "pipeline" {
+  name               = "Pipeline"
+  space_id           = qlik_space.sales_data.id
+  lakehouse_type     = "SNOWFLAKE"
+  type               = "DATA_PIPELINE"
+  storage_connection = qlik_data_connection.snowflake.id
+}
+
+resource "qlik_data_app" "orders" {
+  name       = "Orders"
+  type       = "LANDING"
+  project_id = qlik_data_project.pipeline.id
+}
+
+resource "qlik_data_app" "orders_2" {
+  name        = "Orders"
+  type        = "STORAGE"
+  description = "Stored"
+  project_id  = qlik_data_project.pipeline.id
+}
+
+resource "qlik_data_app_source_selection" "orders_2" {
+  project_id           = qlik_data_project.pipeline.id
+  app_id               = qlik_data_app.orders_2.id
+  source_connection_id = "external"
+  source_selection = [{
+    id          = "e1"
+    name        = "ORDERS"
+    data_app_id = "app-1"
+    schema      = "PUBLIC"
+    database    = "DB"
+    type        = "TABLE"
+    project_id  = "project-1"
+  }]
+}
+`),
+		"imports.tf": []byte(`import {
+  to = qlik_space.sales_data
+  id = "space-1"
+}
+
+import {
+  to = qlik_data_connection.legacy
+  id = "conn-2"
+}
+
+import {
+  to = qlik_data_connection.snowflake
+  id = "conn-1"
+}
+
+import {
+  to = qlik_data_project.pipeline
+  id = "project-1"
+}
+
+import {
+  to = qlik_data_app.orders
+  id = "project-1/app-2"
+}
+
+import {
+  to = qlik_data_app.orders_2
+  id = "project-1/app-1"
+}
+
+import {
+  to = qlik_data_app_source_selection.orders_2
+  id = "project-1/app-1"
+}
+`),
+		"variables.tf": []byte(`variable "legacy_gateway_id" {
+  description = "ID of the data gateway used by the Legacy data connection."
+  type        = string
+}
+
+variable "legacy_password" {
+  description = "Password of the Legacy data connection."
+  type        = string
+  sensitive   = true
+}
+
+variable "snowflake_password" {
+  description = "Password of the Snowflake data connection."
+  type        = string
+  sensitive   = true
+}
+`),
+	}
+
+	if len(files) != len(want) {
+		t.Errorf("got %d files, want %d", len(files), len(want))
+	}
+
+	for name, content := range want {
+		if got := string(files[name]); got != string(content) {
+			t.Errorf("%s: got\n%s\nwant\n%s", name, got, content)
+		}
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	cases := map[string]string{
+		"Sales Data":      "sales_data",
+		"my_space":        "my_space",
+		"  Finance--EU  ": "finance_eu",
+		"2024 Reports":    "_2024_reports",
+		"???":             "unnamed",
+	}
+
+	for display, want := range cases {
+		if got := identifier(display); got != want {
+			t.Errorf("%q: got %q, want %q", display, got, want)
+		}
+	}
+}
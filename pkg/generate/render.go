@@ -0,0 +1,315 @@
+package generate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daniepett/qlik-cloud-client-go/models"
+	"github.com/daniepett/terraform-provider-qlik/pkg/qlik"
+	"github.com/daniepett/terraform-provider-qlik/pkg/resources"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// renderer writes the resources, import blocks and variables of the
+// generated configuration.
+type renderer struct {
+	resources *hclwrite.Body
+	imports   *hclwrite.Body
+	variables *hclwrite.Body
+
+	names names
+
+	// spaces and connections map the IDs of generated objects to their
+	// resource names, so other resources can reference them.
+	spaces      map[string]string
+	connections map[string]string
+}
+
+// render returns the configuration of spaces in resources.tf, their import
+// blocks in imports.tf and the variables for secret connection parameters,
+// such as passwords, which cannot be read back, in variables.tf.
+func render(spaces []space) Files {
+	resources := hclwrite.NewEmptyFile()
+	imports := hclwrite.NewEmptyFile()
+	variables := hclwrite.NewEmptyFile()
+
+	r := renderer{
+		resources:   resources.Body(),
+		imports:     imports.Body(),
+		variables:   variables.Body(),
+		names:       names{},
+		spaces:      map[string]string{},
+		connections: map[string]string{},
+	}
+
+	// Names are handed out up front, so data projects can reference data
+	// connections in spaces that are written after them.
+	for _, s := range spaces {
+		r.spaces[s.ID] = r.names.unique("qlik_space", s.Name)
+		for _, connection := range s.Connections {
+			r.connections[connection.ID] = r.names.unique("qlik_data_connection", connection.Name)
+		}
+	}
+
+	for _, s := range spaces {
+		r.space(s)
+	}
+
+	files := Files{
+		"resources.tf": hclwrite.Format(resources.Bytes()),
+		"imports.tf":   hclwrite.Format(imports.Bytes()),
+	}
+
+	if len(r.variables.Blocks()) > 0 {
+		files["variables.tf"] = hclwrite.Format(variables.Bytes())
+	}
+
+	return files
+}
+
+// space writes a space and everything in it.
+func (r *renderer) space(s space) {
+	name := r.spaces[s.ID]
+
+	body := r.resource("qlik_space", name, s.ID)
+	body.SetAttributeValue("name", cty.StringVal(s.Name))
+	body.SetAttributeValue("type", cty.StringVal(s.Type))
+	if s.Description != "" {
+		body.SetAttributeValue("description", cty.StringVal(s.Description))
+	}
+
+	for _, connection := range s.Connections {
+		r.connection(connection)
+	}
+
+	for _, p := range s.Projects {
+		r.project(p)
+	}
+}
+
+// connection writes a data connection with the connection_parameters of the
+// connector of its type. Secret parameters, such as the password, are taken
+// from variables, as the API never returns them.
+func (r *renderer) connection(connection models.GetConnectionResponse) {
+	name := r.connections[connection.ID]
+	properties := qlik.ParseConnectStatement(connection.ConnectStatement)
+
+	body := r.resource("qlik_data_connection", name, connection.ID)
+	body.SetAttributeValue("name", cty.StringVal(connection.Name))
+	r.reference(body, "space_id", "qlik_space", r.spaces, connection.SpaceID)
+	body.SetAttributeValue("type", cty.StringVal(connection.DataSourceID))
+	if gatewayID, ok := properties["agentid"]; ok && gatewayID != "" {
+		body.SetAttributeValue("gateway_id", cty.StringVal(gatewayID))
+	} else {
+		// The gateway cannot be told from the connect statement, so it is
+		// left to a variable for the user to set.
+		variable := r.variable(name+"_gateway_id", fmt.Sprintf("ID of the data gateway used by the %s data connection.", connection.Name), false)
+		body.SetAttributeTraversal("gateway_id", traversal("var", variable))
+	}
+
+	// Connections of types the provider has no connector for are written
+	// without parameters, so the type is reported when planning.
+	connectionParameters, _ := resources.ConnectionParameters(connection.DataSourceID)
+
+	var parameters []hclwrite.ObjectAttrTokens
+	for _, p := range connectionParameters {
+		if !p.Secret {
+			if value, ok := properties[p.Property]; ok {
+				parameters = append(parameters, hclwrite.ObjectAttrTokens{
+					Name:  hclwrite.TokensForIdentifier(p.Attribute),
+					Value: hclwrite.TokensForValue(cty.StringVal(value)),
+				})
+			}
+			continue
+		}
+
+		description := strings.ToUpper(p.Attribute[:1]) + strings.ReplaceAll(p.Attribute[1:], "_", " ")
+		variable := r.variable(name+"_"+p.Attribute, fmt.Sprintf("%s of the %s data connection.", description, connection.Name), true)
+		parameters = append(parameters, hclwrite.ObjectAttrTokens{
+			Name:  hclwrite.TokensForIdentifier(p.Attribute),
+			Value: hclwrite.TokensForTraversal(traversal("var", variable)),
+		})
+	}
+	body.SetAttributeRaw("connection_parameters", hclwrite.TokensForObject(parameters))
+}
+
+// variable writes a string variable named after name and returns its name.
+func (r *renderer) variable(name, description string, sensitive bool) string {
+	variable := r.names.unique("variable", name)
+
+	if len(r.variables.Blocks()) > 0 {
+		r.variables.AppendNewline()
+	}
+
+	v := r.variables.AppendNewBlock("variable", []string{variable}).Body()
+	v.SetAttributeValue("description", cty.StringVal(description))
+	v.SetAttributeRaw("type", hclwrite.TokensForIdentifier("string"))
+	if sensitive {
+		v.SetAttributeValue("sensitive", cty.True)
+	}
+
+	return variable
+}
+
+// project writes a data project and its data apps.
+func (r *renderer) project(p project) {
+	dataProject := p.DataProject
+	name := r.names.unique("qlik_data_project", dataProject.Name)
+
+	body := r.resource("qlik_data_project", name, dataProject.ID)
+	body.SetAttributeValue("name", cty.StringVal(dataProject.Name))
+	if dataProject.Description != "" {
+		body.SetAttributeValue("description", cty.StringVal(dataProject.Description))
+	}
+	r.reference(body, "space_id", "qlik_space", r.spaces, p.Info.SpaceID)
+	body.SetAttributeValue("lakehouse_type", cty.StringVal(dataProject.LakehouseType))
+	body.SetAttributeValue("type", cty.StringVal(dataProject.Type))
+	r.reference(body, "storage_connection", "qlik_data_connection", r.connections, dataProject.StorageConnection)
+
+	for _, a := range p.Apps {
+		r.app(dataProject.ID, name, a)
+	}
+}
+
+// app writes a data app and its source selection.
+func (r *renderer) app(projectID, projectName string, a app) {
+	name := r.names.unique("qlik_data_app", a.Name)
+	importID := projectID + "/" + a.ID
+
+	body := r.resource("qlik_data_app", name, importID)
+	body.SetAttributeValue("name", cty.StringVal(a.Name))
+	body.SetAttributeValue("type", cty.StringVal(a.Type))
+	if a.Description != "" {
+		body.SetAttributeValue("description", cty.StringVal(a.Description))
+	}
+	body.SetAttributeTraversal("project_id", traversal("qlik_data_project", projectName, "id"))
+
+	if a.Selection == nil {
+		return
+	}
+
+	selection := a.Selection.SourceSelection.DataEntitiesSelection
+
+	body = r.resource("qlik_data_app_source_selection", r.names.unique("qlik_data_app_source_selection", name), importID)
+	body.SetAttributeTraversal("project_id", traversal("qlik_data_project", projectName, "id"))
+	body.SetAttributeTraversal("app_id", traversal("qlik_data_app", name, "id"))
+	r.reference(body, "source_connection_id", "qlik_data_connection", r.connections, selection.SourceConnectionID)
+
+	entities := []hclwrite.Tokens{}
+	for _, entity := range selection.DataEntities {
+		entities = append(entities, hclwrite.TokensForObject([]hclwrite.ObjectAttrTokens{
+			stringAttr("id", entity.ID),
+			stringAttr("name", entity.Name),
+			stringAttr("data_app_id", entity.DataAppID),
+			stringAttr("schema", entity.Schema),
+			stringAttr("database", entity.Database),
+			stringAttr("type", entity.Type),
+			stringAttr("project_id", entity.ProjectID),
+		}))
+	}
+	body.SetAttributeRaw("source_selection", hclwrite.TokensForTuple(entities))
+}
+
+// resource starts a resource block and adds the import block for it.
+func (r *renderer) resource(resourceType, name, importID string) *hclwrite.Body {
+	if len(r.resources.Blocks()) > 0 {
+		r.resources.AppendNewline()
+	}
+
+	if len(r.imports.Blocks()) > 0 {
+		r.imports.AppendNewline()
+	}
+
+	imp := r.imports.AppendNewBlock("import", nil).Body()
+	imp.SetAttributeTraversal("to", traversal(resourceType, name))
+	imp.SetAttributeValue("id", cty.StringVal(importID))
+
+	return r.resources.AppendNewBlock("resource", []string{resourceType, name}).Body()
+}
+
+// reference sets attribute to the ID of the generated resource with the given
+// ID, or to the ID itself when the object it refers to is not generated.
+func (r *renderer) reference(body *hclwrite.Body, attribute, resourceType string, generated map[string]string, id string) {
+	if name, ok := generated[id]; ok {
+		body.SetAttributeTraversal(attribute, traversal(resourceType, name, "id"))
+		return
+	}
+
+	body.SetAttributeValue(attribute, cty.StringVal(id))
+}
+
+// traversal returns a reference such as qlik_space.example.id.
+func traversal(root string, attributes ...string) hcl.Traversal {
+	t := hcl.Traversal{hcl.TraverseRoot{Name: root}}
+	for _, attribute := range attributes {
+		t = append(t, hcl.TraverseAttr{Name: attribute})
+	}
+
+	return t
+}
+
+// stringAttr returns an object attribute with a string value.
+func stringAttr(name, value string) hclwrite.ObjectAttrTokens {
+	return hclwrite.ObjectAttrTokens{
+		Name:  hclwrite.TokensForIdentifier(name),
+		Value: hclwrite.TokensForValue(cty.StringVal(value)),
+	}
+}
+
+// names hands out resource names that are unique per resource type.
+type names map[string]map[string]bool
+
+// unique returns a name for the object with the given display name, adding a
+// numeric suffix when another object of the type already has it.
+func (n names) unique(resourceType, display string) string {
+	taken, ok := n[resourceType]
+	if !ok {
+		taken = map[string]bool{}
+		n[resourceType] = taken
+	}
+
+	base := identifier(display)
+	name := base
+	for i := 2; taken[name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+
+	taken[name] = true
+
+	return name
+}
+
+// identifier turns a display name into a Terraform identifier, lower-casing it
+// and joining its words with underscores.
+func identifier(display string) string {
+	var b strings.Builder
+
+	separate := false
+	for _, c := range strings.ToLower(display) {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			separate = true
+			continue
+		}
+
+		if separate && b.Len() > 0 {
+			b.WriteByte('_')
+		}
+		separate = false
+
+		b.WriteRune(c)
+	}
+
+	id := b.String()
+	if id == "" {
+		return "unnamed"
+	}
+
+	// Identifiers cannot start with a digit.
+	if id[0] >= '0' && id[0] <= '9' {
+		id = "_" + id
+	}
+
+	return id
+}
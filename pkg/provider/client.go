@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+	"github.com/daniepett/terraform-provider-qlik/pkg/transport"
+)
+
+const (
+	// requestTimeout bounds how long a single attempt waits for a response.
+	requestTimeout = 10 * time.Second
+
+	defaultMaxRetries = 4
+	defaultMinBackoff = 1 * time.Second
+	defaultMaxBackoff = 30 * time.Second
+
+	// defaultMaxConcurrentRequests keeps Terraform's default parallelism
+	// from overwhelming the tenant when modules fan out.
+	defaultMaxConcurrentRequests = 5
+)
+
+// clientConfig holds the resolved provider settings used to build the Qlik
+// Cloud client.
+type clientConfig struct {
+	HostURL      string
+	ClientID     string
+	ClientSecret string
+	APIKey       string
+	Retry        transport.RetryOptions
+	Limit        transport.LimitOptions
+}
+
+// NewClientFromEnv creates a Qlik Cloud client the same way the provider
+// does, taking every setting from the QLIK_* environment variables. It is
+// used by tooling that runs outside of Terraform, such as the generate
+// command.
+func NewClientFromEnv(ctx context.Context) (*qlikcloud.Client, error) {
+	cfg, diags := qlikProviderModel{}.clientConfig()
+	if diags.HasError() {
+		var messages []string
+		for _, d := range diags.Errors() {
+			messages = append(messages, fmt.Sprintf("%s: %s", d.Summary(), d.Detail()))
+		}
+		return nil, errors.New(strings.Join(messages, "\n"))
+	}
+
+	return newClient(ctx, cfg)
+}
+
+// newClient creates a Qlik Cloud client for the configured host. The client is
+// assembled here rather than through qlikcloud.NewClient, which always derives
+// the host from the tenant and region before requesting a token.
+func newClient(ctx context.Context, cfg clientConfig) (*qlikcloud.Client, error) {
+	// The timeout is applied to each attempt by the base transport instead of
+	// the http.Client, whose timeout would also cover the retries.
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.ResponseHeaderTimeout = requestTimeout
+
+	// Limits are applied below the retries, so every attempt counts against
+	// the shared concurrency limit and request budget.
+	limited := transport.NewLimitTransport(transport.NewLoggingTransport(base), cfg.Limit)
+
+	client := &qlikcloud.Client{
+		HostURL: cfg.HostURL,
+		HTTPClient: &http.Client{
+			Transport: transport.NewRetryTransport(limited, cfg.Retry),
+		},
+	}
+
+	// API keys are sent as bearer tokens as-is, so there is no token to request.
+	if cfg.APIKey != "" {
+		client.Token = cfg.APIKey
+		return client, nil
+	}
+
+	client.Auth = qlikcloud.AuthStruct{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		GrantType:    "client_credentials",
+	}
+
+	ar, err := transport.WithContext(ctx, client).GetToken()
+	if err != nil {
+		return nil, err
+	}
+
+	client.Token = ar.AccessToken
+
+	return client, nil
+}
+
+// tenantURL returns the base URL of the Qlik Cloud tenant derived from its ID
+// and region.
+func tenantURL(tenantID, region string) string {
+	return fmt.Sprintf("https://%s.%s.qlikcloud.com", tenantID, region)
+}
+
+// parseHost validates a host override and returns it as a base URL. A bare
+// hostname is treated as https, and plain http is only accepted when insecure
+// is set.
+func parseHost(host string, insecure bool) (string, error) {
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+
+	u, err := url.Parse(host)
+	if err != nil {
+		return "", err
+	}
+
+	if u.Host == "" {
+		return "", fmt.Errorf("%q does not contain a hostname", host)
+	}
+
+	switch u.Scheme {
+	case "https":
+	case "http":
+		if !insecure {
+			return "", fmt.Errorf("%q uses plain http, set insecure to true to allow it", host)
+		}
+	default:
+		return "", fmt.Errorf("%q uses the unsupported scheme %q", host, u.Scheme)
+	}
+
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("%q must not contain a query or fragment", host)
+	}
+
+	return strings.TrimRight(u.String(), "/"), nil
+}
@@ -0,0 +1,38 @@
+package provider
+
+import "testing"
+
+func TestParseHost(t *testing.T) {
+	cases := []struct {
+		host     string
+		insecure bool
+		want     string
+		wantErr  bool
+	}{
+		{host: "analytics.example.com", want: "https://analytics.example.com"},
+		{host: "https://analytics.example.com/", want: "https://analytics.example.com"},
+		{host: "https://analytics.example.com:8443", want: "https://analytics.example.com:8443"},
+		{host: "http://localhost:8080", wantErr: true},
+		{host: "http://localhost:8080", insecure: true, want: "http://localhost:8080"},
+		{host: "ftp://analytics.example.com", insecure: true, wantErr: true},
+		{host: "https://", wantErr: true},
+		{host: "https://analytics.example.com/?tenant=x", wantErr: true},
+	}
+
+	for _, c := range cases {
+		got, err := parseHost(c.host, c.insecure)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("parseHost(%q, %t) = %q, want error", c.host, c.insecure, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseHost(%q, %t) returned error: %s", c.host, c.insecure, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("parseHost(%q, %t) = %q, want %q", c.host, c.insecure, got, c.want)
+		}
+	}
+}
@@ -2,16 +2,24 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strconv"
+	"time"
 
-	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
 	"github.com/daniepett/terraform-provider-qlik/pkg/datasources"
 	"github.com/daniepett/terraform-provider-qlik/pkg/resources"
+	"github.com/daniepett/terraform-provider-qlik/pkg/transport"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -33,10 +41,18 @@ type qlikProvider struct {
 }
 
 type qlikProviderModel struct {
-	TenantID     types.String `tfsdk:"tenant_id"`
-	Region       types.String `tfsdk:"region"`
-	ClientID     types.String `tfsdk:"client_id"`
-	ClientSecret types.String `tfsdk:"client_secret"`
+	TenantID              types.String  `tfsdk:"tenant_id"`
+	Region                types.String  `tfsdk:"region"`
+	ClientID              types.String  `tfsdk:"client_id"`
+	ClientSecret          types.String  `tfsdk:"client_secret"`
+	APIKey                types.String  `tfsdk:"api_key"`
+	Host                  types.String  `tfsdk:"host"`
+	Insecure              types.Bool    `tfsdk:"insecure"`
+	MaxRetries            types.Int64   `tfsdk:"max_retries"`
+	MinBackoff            types.String  `tfsdk:"min_backoff"`
+	MaxBackoff            types.String  `tfsdk:"max_backoff"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
 }
 
 func (p *qlikProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -48,10 +64,40 @@ func (p *qlikProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"tenant_id": schema.StringAttribute{
-				Required: true,
+				Optional: true,
 			},
 			"region": schema.StringAttribute{
-				Required: true,
+				Optional: true,
+			},
+			"host": schema.StringAttribute{
+				Optional: true,
+			},
+			"insecure": schema.BoolAttribute{
+				Optional: true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"min_backoff": schema.StringAttribute{
+				Optional: true,
+			},
+			"max_backoff": schema.StringAttribute{
+				Optional: true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Optional: true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
 			},
 			"client_id": schema.StringAttribute{
 				Optional: true,
@@ -60,6 +106,16 @@ func (p *qlikProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 				Optional:  true,
 				Sensitive: true,
 			},
+			"api_key": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(
+						path.MatchRoot("client_id"),
+						path.MatchRoot("client_secret"),
+					),
+				},
+			},
 		},
 	}
 }
@@ -73,6 +129,35 @@ func (p *qlikProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	}
 
+	cfg, diags := config.clientConfig()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create a new Qlik Cloud client using the configuration values
+	client, err := newClient(ctx, cfg)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Qlik Cloud Client",
+			"An unexpected error occurred when creating the Qlik Cloud client. "+
+				"If the error is not clear, please contact the provider developers.\n\n"+
+				"Qlik Cloud Client Error: "+err.Error(),
+		)
+		return
+	}
+
+	// Make the Qlik client available during DataSource and Resource
+	// type Configure methods.
+	resp.DataSourceData = client
+	resp.ResourceData = client
+}
+
+// clientConfig resolves the settings used to build the Qlik Cloud client,
+// defaulting unset values to the environment variables.
+func (config qlikProviderModel) clientConfig() (clientConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
 	// Default values to environment variables, but override
 	// with Terraform configuration value if set.
 
@@ -80,6 +165,9 @@ func (p *qlikProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 	client_secret := os.Getenv("QLIK_CLIENT_SECRET")
 	tenant_id := os.Getenv("QLIK_TENANT_ID")
 	region := os.Getenv("QLIK_REGION")
+	api_key := os.Getenv("QLIK_API_KEY")
+	host := os.Getenv("QLIK_HOST")
+	insecure, _ := strconv.ParseBool(os.Getenv("QLIK_INSECURE"))
 
 	if !config.TenantID.IsNull() {
 		tenant_id = config.TenantID.ValueString()
@@ -97,69 +185,171 @@ func (p *qlikProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		client_secret = config.ClientSecret.ValueString()
 	}
 
+	if !config.APIKey.IsNull() {
+		api_key = config.APIKey.ValueString()
+	}
+
+	if !config.Host.IsNull() {
+		host = config.Host.ValueString()
+	}
+
+	if !config.Insecure.IsNull() {
+		insecure = config.Insecure.ValueBool()
+	}
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
-	if tenant_id == "" {
-		resp.Diagnostics.AddAttributeError(
+	var host_url string
+	if host != "" {
+		var err error
+		host_url, err = parseHost(host, insecure)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("host"),
+				"Invalid Qlik Cloud Host",
+				"The provider cannot create the Qlik Cloud API client as the Qlik Cloud host is invalid. "+
+					"Set the host value in the configuration or the QLIK_HOST environment variable to an https URL or hostname.\n\n"+
+					"Error: "+err.Error(),
+			)
+		}
+	}
+
+	// The tenant ID and region are only used to derive the host, so they are
+	// not needed when the host is overridden.
+
+	if host == "" && tenant_id == "" {
+		diags.AddAttributeError(
 			path.Root("tenant_id"),
 			"Missing Qlik Cloud Tenant ID",
 			"The provider cannot create the Qlik Cloud API client as there is a missing or empty value for the Qlik Cloud Tenant ID. "+
-				"Set the tenant_id value in the configuration or use the QLIK_CLOUD_TENANT_ID environment variable. "+
+				"Set the tenant_id value in the configuration or use the QLIK_TENANT_ID environment variable, "+
+				"or set the tenant host through the host value or the QLIK_HOST environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
 
-	if region == "" {
-		resp.Diagnostics.AddAttributeError(
+	if host == "" && region == "" {
+		diags.AddAttributeError(
 			path.Root("region"),
 			"Missing Qlik Cloud Region",
 			"The provider cannot create the Qlik Cloud API client as there is a missing or empty value for the Qlik Cloud region. "+
-				"Set the region value in the configuration or use the QLIK_CLOUD_REGION environment variable. "+
+				"Set the region value in the configuration or use the QLIK_REGION environment variable, "+
+				"or set the tenant host through the host value or the QLIK_HOST environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
 
-	if client_id == "" {
-		resp.Diagnostics.AddAttributeError(
+	// An API key and the OAuth client credentials are alternative ways of
+	// authenticating, so exactly one of them has to be provided.
+
+	if api_key != "" && (client_id != "" || client_secret != "") {
+		diags.AddAttributeError(
+			path.Root("api_key"),
+			"Conflicting Qlik Cloud Credentials",
+			"The provider cannot create the Qlik Cloud API client as both an API key and OAuth client credentials are configured. "+
+				"Set either the api_key value (or the QLIK_API_KEY environment variable) or the client_id and client_secret values "+
+				"(or the QLIK_CLIENT_ID and QLIK_CLIENT_SECRET environment variables), but not both.",
+		)
+	}
+
+	if api_key == "" && client_id == "" {
+		diags.AddAttributeError(
 			path.Root("client_id"),
 			"Missing Qlik Cloud Client ID",
 			"The provider cannot create the Qlik Cloud API client as there is a missing or empty value for the Qlik Cloud Client ID. "+
-				"Set the client_id value in the configuration or use the QLIK_CLOUD_CLIENT_ID environment variable. "+
+				"Set the client_id value in the configuration or use the QLIK_CLIENT_ID environment variable, "+
+				"or authenticate with an API key through the api_key value or the QLIK_API_KEY environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
 
-	if client_secret == "" {
-		resp.Diagnostics.AddAttributeError(
+	if api_key == "" && client_secret == "" {
+		diags.AddAttributeError(
 			path.Root("client_secret"),
 			"Missing Qlik Cloud Client Secret",
 			"The provider cannot create the Qlik Cloud API client as there is a missing or empty value for the Qlik Cloud Client Secret. "+
-				"Set the client_secret value in the configuration or use the QLIK_CLOUD_CLIENT_SECRET environment variable. "+
+				"Set the client_secret value in the configuration or use the QLIK_CLIENT_SECRET environment variable, "+
+				"or authenticate with an API key through the api_key value or the QLIK_API_KEY environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
 
-	if resp.Diagnostics.HasError() {
-		return
+	// Requests are retried with exponential backoff, which can be tuned for
+	// tenants that are rate limited more aggressively.
+
+	retry := transport.RetryOptions{
+		MaxRetries: defaultMaxRetries,
+		MinBackoff: defaultMinBackoff,
+		MaxBackoff: defaultMaxBackoff,
 	}
 
-	// Create a new Qlik Cloud client using the configuration values
-	client, err := qlikcloud.NewClient(&tenant_id, &region, &client_id, &client_secret)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create Qlik Cloud Client",
-			"An unexpected error occurred when creating the Qlik Cloud client. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
-				"Qlik Cloud Client Error: "+err.Error(),
+	if !config.MaxRetries.IsNull() {
+		retry.MaxRetries = int(config.MaxRetries.ValueInt64())
+	}
+
+	if !config.MinBackoff.IsNull() {
+		retry.MinBackoff = parseBackoff(config.MinBackoff, path.Root("min_backoff"), &diags)
+	}
+
+	if !config.MaxBackoff.IsNull() {
+		retry.MaxBackoff = parseBackoff(config.MaxBackoff, path.Root("max_backoff"), &diags)
+	}
+
+	if retry.MaxBackoff < retry.MinBackoff {
+		diags.AddAttributeError(
+			path.Root("max_backoff"),
+			"Invalid Retry Backoff",
+			fmt.Sprintf("The max_backoff value (%s) must not be shorter than the min_backoff value (%s).", retry.MaxBackoff, retry.MinBackoff),
 		)
-		return
 	}
 
-	// Make the Qlik client available during DataSource and Resource
-	// type Configure methods.
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	// All resources and data sources share the client, and with it the
+	// concurrency limit and request budget. Zero disables either.
+
+	limit := transport.LimitOptions{
+		MaxConcurrentRequests: defaultMaxConcurrentRequests,
+	}
+
+	if !config.MaxConcurrentRequests.IsNull() {
+		limit.MaxConcurrentRequests = int(config.MaxConcurrentRequests.ValueInt64())
+	}
+
+	if !config.RequestsPerSecond.IsNull() {
+		limit.RequestsPerSecond = config.RequestsPerSecond.ValueFloat64()
+	}
+
+	if diags.HasError() {
+		return clientConfig{}, diags
+	}
+
+	if host_url == "" {
+		host_url = tenantURL(tenant_id, region)
+	}
+
+	return clientConfig{
+		HostURL:      host_url,
+		ClientID:     client_id,
+		ClientSecret: client_secret,
+		APIKey:       api_key,
+		Retry:        retry,
+		Limit:        limit,
+	}, diags
+}
+
+// parseBackoff parses a backoff duration such as "500ms" or "2s", adding an
+// attribute error when it is not a positive duration.
+func parseBackoff(value types.String, attribute path.Path, diags *diag.Diagnostics) time.Duration {
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil || d <= 0 {
+		diags.AddAttributeError(
+			attribute,
+			"Invalid Retry Backoff",
+			fmt.Sprintf("The %s value %q must be a positive duration such as \"500ms\" or \"2s\".", attribute, value.ValueString()),
+		)
+	}
+
+	return d
 }
 
 // DataSources defines the data sources implemented in the provider.
@@ -168,8 +358,13 @@ func (p *qlikProvider) DataSources(_ context.Context) []func() datasource.DataSo
 		datasources.NewSpacesDataSource,
 		datasources.NewSpaceDataSource,
 		datasources.NewDataGatewayDataSource,
+		datasources.NewDataGatewaysDataSource,
 		datasources.NewDataConnectionsDataSource,
 		datasources.NewSourceEntitiesDataSource,
+		datasources.NewUserDataSource,
+		datasources.NewUsersDataSource,
+		datasources.NewGroupDataSource,
+		datasources.NewGroupsDataSource,
 	}
 }
 
@@ -181,5 +376,8 @@ func (p *qlikProvider) Resources(_ context.Context) []func() resource.Resource {
 		resources.NewDataProjectResource,
 		resources.NewDataAppResource,
 		resources.NewDataAppSourceSelectionResource,
+		resources.NewSpaceAssignmentResource,
+		resources.NewSpaceAssignmentsResource,
+		resources.NewUserResource,
 	}
 }
@@ -0,0 +1,181 @@
+// Package qlik implements the Qlik Cloud API calls the provider needs that
+// the Qlik Cloud client does not cover, such as listing every page of a
+// collection. Requests are sent through the client's HTTP client, so they
+// share its credentials, retries, limits and logging.
+package qlik
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+)
+
+// pageLimit is the page size requested from paginated endpoints.
+const pageLimit = 100
+
+// Link is a hypermedia link returned by the Qlik Cloud APIs.
+type Link struct {
+	Href string `json:"href"`
+}
+
+// Links holds the pagination links of a collection response.
+type Links struct {
+	Self Link `json:"self"`
+	Next Link `json:"next"`
+	Prev Link `json:"prev"`
+}
+
+// page is a single page of a collection response.
+type page[T any] struct {
+	Data  []T   `json:"data"`
+	Links Links `json:"links"`
+}
+
+// decodePage decodes a page of a collection response into its items and its
+// pagination links.
+type decodePage[T any] func(body []byte) ([]T, Links, error)
+
+// decodeDataPage decodes a page of a collection that lists its items under
+// data, as most Qlik Cloud collections do.
+func decodeDataPage[T any](body []byte) ([]T, Links, error) {
+	p := page[T]{}
+	err := json.Unmarshal(body, &p)
+
+	return p.Data, p.Links, err
+}
+
+// list fetches every item of a collection, starting at path with the given
+// query and following the next links until the last page. A maxItems of
+// zero or less returns all items.
+func list[T any](c *qlikcloud.Client, path string, query url.Values, maxItems int) ([]T, error) {
+	return listPages(c, path, query, maxItems, decodeDataPage[T])
+}
+
+// listPages is list for collections whose pages are decoded by decode, such
+// as those that list their items under another key than data.
+func listPages[T any](c *qlikcloud.Client, path string, query url.Values, maxItems int, decode decodePage[T]) ([]T, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+
+	if query.Get("limit") == "" {
+		query.Set("limit", fmt.Sprint(pageLimit))
+	}
+
+	next := fmt.Sprintf("%s%s?%s", c.HostURL, path, query.Encode())
+
+	var items []T
+	for next != "" {
+		req, err := http.NewRequest("GET", next, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := doRequest(c, req)
+		if err != nil {
+			return nil, err
+		}
+
+		data, links, err := decode(body)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, data...)
+
+		if maxItems > 0 && len(items) >= maxItems {
+			return items[:maxItems], nil
+		}
+
+		next, err = resolve(c, links.Next.Href)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return items, nil
+}
+
+// resolve turns a link returned by the API into an absolute URL on the
+// client's host.
+func resolve(c *qlikcloud.Client, href string) (string, error) {
+	if href == "" {
+		return "", nil
+	}
+
+	base, err := url.Parse(c.HostURL + "/")
+	if err != nil {
+		return "", err
+	}
+
+	ref, err := url.Parse(href)
+	if err != nil {
+		return "", err
+	}
+
+	return base.ResolveReference(ref).String(), nil
+}
+
+// doJSON sends a request to path on the client's host with body encoded as
+// JSON, if any, and decodes the response into out, if any.
+func doJSON(c *qlikcloud.Client, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		rb, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		reader = bytes.NewReader(rb)
+	}
+
+	req, err := http.NewRequest(method, c.HostURL+path, reader)
+	if err != nil {
+		return err
+	}
+
+	rb, err := doRequest(c, req)
+	if err != nil {
+		return err
+	}
+
+	if out == nil || len(rb) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(rb, out)
+}
+
+// doRequest sends req with the client's credentials and returns the response
+// body, the same way the Qlik Cloud client sends its own requests.
+func doRequest(c *qlikcloud.Client, req *http.Request) ([]byte, error) {
+	if c.Token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, &Error{StatusCode: res.StatusCode, Body: strings.TrimSpace(string(body))}
+	}
+
+	return body, nil
+}
@@ -0,0 +1,36 @@
+package qlik
+
+import "strings"
+
+// ParseConnectStatement returns the properties of a connect statement such
+// as
+//
+//	CUSTOM CONNECT TO "provider=QlikConnectorsCommonService.exe;sourceType=reptgt_qdisnowflake;server=acme;"
+//
+// keyed by their lower-cased name.
+func ParseConnectStatement(statement string) map[string]string {
+	// Only the quoted part holds the properties.
+	if start := strings.Index(statement, `"`); start >= 0 {
+		statement = statement[start+1:]
+		if end := strings.LastIndex(statement, `"`); end >= 0 {
+			statement = statement[:end]
+		}
+	}
+
+	properties := map[string]string{}
+	for _, pair := range strings.Split(statement, ";") {
+		key, value, found := strings.Cut(pair, "=")
+		if !found {
+			continue
+		}
+
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" {
+			continue
+		}
+
+		properties[key] = strings.TrimSpace(value)
+	}
+
+	return properties
+}
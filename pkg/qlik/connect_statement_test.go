@@ -0,0 +1,50 @@
+package qlik
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseConnectStatement(t *testing.T) {
+	cases := map[string]struct {
+		statement string
+		want      map[string]string
+	}{
+		"custom connect": {
+			statement: `CUSTOM CONNECT TO "provider=QlikConnectorsCommonService.exe;sourceType=reptgt_qdisnowflake;agentId=gw-1;server=acme.snowflakecomputing.com;port=443;username=loader;metadataschema=META;"`,
+			want: map[string]string{
+				"provider":       "QlikConnectorsCommonService.exe",
+				"sourcetype":     "reptgt_qdisnowflake",
+				"agentid":        "gw-1",
+				"server":         "acme.snowflakecomputing.com",
+				"port":           "443",
+				"username":       "loader",
+				"metadataschema": "META",
+			},
+		},
+		"unquoted": {
+			statement: `server=acme;database=SALES`,
+			want: map[string]string{
+				"server":   "acme",
+				"database": "SALES",
+			},
+		},
+		"values containing equals": {
+			statement: `CUSTOM CONNECT TO "provider=x;filter=a=b;"`,
+			want: map[string]string{
+				"provider": "x",
+				"filter":   "a=b",
+			},
+		},
+		"empty": {
+			statement: ``,
+			want:      map[string]string{},
+		},
+	}
+
+	for name, c := range cases {
+		if got := ParseConnectStatement(c.statement); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: got %v, want %v", name, got, c.want)
+		}
+	}
+}
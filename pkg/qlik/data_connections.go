@@ -0,0 +1,25 @@
+package qlik
+
+import (
+	"net/url"
+
+	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+	"github.com/daniepett/qlik-cloud-client-go/models"
+)
+
+// DataConnectionFilter narrows the data connections returned by
+// ListDataConnections. Empty fields are not filtered on.
+type DataConnectionFilter struct {
+	SpaceID string
+}
+
+// ListDataConnections returns every data connection matching filter.
+func ListDataConnections(c *qlikcloud.Client, filter DataConnectionFilter) ([]models.GetConnectionResponse, error) {
+	query := url.Values{}
+
+	if filter.SpaceID != "" {
+		query.Set("spaceId", filter.SpaceID)
+	}
+
+	return list[models.GetConnectionResponse](c, "/api/v1/data-connections", query, 0)
+}
@@ -0,0 +1,51 @@
+package qlik
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+	"github.com/daniepett/qlik-cloud-client-go/models"
+)
+
+// DataGateway is a Data Movement gateway, including the time it last
+// reported to the tenant, which the Qlik Cloud client leaves out.
+type DataGateway struct {
+	models.DataGateway
+	LastHeartbeat string `json:"lastHeartbeat"`
+}
+
+// Status returns the state of the gateway in lower case, such as "connected"
+// or "disconnected".
+func (g DataGateway) Status() string {
+	return strings.ToLower(g.State)
+}
+
+// dataGateways is a page of the data gateways collection, which lists the
+// gateways under agents rather than data.
+type dataGateways struct {
+	DataGateways []DataGateway `json:"agents"`
+	Links        Links         `json:"links"`
+}
+
+// ListDataGateways returns every Data Movement gateway of the tenant.
+func ListDataGateways(c *qlikcloud.Client) ([]DataGateway, error) {
+	return listPages(c, "/api/v1/replicate-agents", nil, 0, func(body []byte) ([]DataGateway, Links, error) {
+		p := dataGateways{}
+		err := json.Unmarshal(body, &p)
+
+		return p.DataGateways, p.Links, err
+	})
+}
+
+// GetDataGateway returns the Data Movement gateway with the given ID.
+func GetDataGateway(c *qlikcloud.Client, id string) (*DataGateway, error) {
+	gateway := DataGateway{}
+	err := doJSON(c, "GET", "/api/v1/replicate-agents/"+url.PathEscape(id), nil, &gateway)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gateway, nil
+}
@@ -0,0 +1,45 @@
+package qlik
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+)
+
+func TestListDataGateways(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("next") == "" {
+			if r.URL.Query().Get("limit") != "100" {
+				t.Errorf("got limit %q, want 100", r.URL.Query().Get("limit"))
+			}
+
+			_, _ = w.Write([]byte(`{"agents":[{"id":"g1","name":"East","state":"CONNECTED","lastHeartbeat":"2024-01-02T03:04:05Z"}],"links":{"next":{"href":"` + server.URL + `/api/v1/replicate-agents?next=2"}}}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"agents":[{"id":"g2","name":"West","state":"DISCONNECTED"}]}`))
+	}))
+	defer server.Close()
+
+	client := &qlikcloud.Client{HostURL: server.URL, HTTPClient: server.Client()}
+
+	gateways, err := ListDataGateways(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(gateways) != 2 || gateways[0].ID != "g1" || gateways[1].ID != "g2" {
+		t.Fatalf("got gateways %+v, want g1 and g2", gateways)
+	}
+
+	if gateways[0].Status() != "connected" || gateways[1].Status() != "disconnected" {
+		t.Errorf("got statuses %q and %q, want connected and disconnected", gateways[0].Status(), gateways[1].Status())
+	}
+
+	if gateways[0].LastHeartbeat != "2024-01-02T03:04:05Z" {
+		t.Errorf("got last heartbeat %q", gateways[0].LastHeartbeat)
+	}
+}
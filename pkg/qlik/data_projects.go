@@ -0,0 +1,68 @@
+package qlik
+
+import (
+	"encoding/json"
+	"net/url"
+
+	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+	"github.com/daniepett/qlik-cloud-client-go/models"
+)
+
+// dataProject is a data project as listed by the data projects collection.
+type dataProject struct {
+	ID   string      `json:"id"`
+	Key  string      `json:"key"`
+	Info models.Info `json:"info"`
+}
+
+// dataProjects is a page of the data projects collection, which lists the
+// projects under dataProjects rather than data.
+type dataProjects struct {
+	DataProjects []dataProject `json:"dataProjects"`
+	Links        Links         `json:"links"`
+}
+
+// ListDataProjects returns every data project in the space with the given ID.
+// The collection only lists the projects, so each one in the space is read
+// in full.
+func ListDataProjects(c *qlikcloud.Client, spaceID string) ([]models.DataProjectResponse, error) {
+	query := url.Values{}
+	query.Set("spaceId", spaceID)
+
+	listed, err := listPages(c, "/api/v1/data-projects", query, 0, func(body []byte) ([]dataProject, Links, error) {
+		p := dataProjects{}
+		err := json.Unmarshal(body, &p)
+
+		return p.DataProjects, p.Links, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var projects []models.DataProjectResponse
+	for _, p := range listed {
+		// Filter on the space again in case the collection ignores the
+		// query, so that only the projects in the space are read.
+		if p.Info.SpaceID != "" && p.Info.SpaceID != spaceID {
+			continue
+		}
+
+		id := p.ID
+		if id == "" {
+			id = p.Key
+		}
+
+		project, err := c.GetDataProject(id)
+		if err != nil {
+			return nil, err
+		}
+
+		if project.Info.SpaceID != spaceID {
+			continue
+		}
+
+		projects = append(projects, *project)
+	}
+
+	return projects, nil
+}
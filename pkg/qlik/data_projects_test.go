@@ -0,0 +1,43 @@
+package qlik
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+)
+
+func TestListDataProjects(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/data-projects":
+			if r.URL.Query().Get("next") == "" {
+				_, _ = w.Write([]byte(`{"dataProjects":[{"id":"p1","info":{"spaceId":"s1"}},{"id":"p2","info":{"spaceId":"s2"}}],"links":{"next":{"href":"` + server.URL + `/api/v1/data-projects?next=2"}}}`))
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"dataProjects":[{"key":"p3"}]}`))
+		case "/api/v1/data-projects/p1":
+			_, _ = w.Write([]byte(`{"key":"p1","info":{"spaceId":"s1"},"dataProject":{"id":"p1","name":"Pipeline"}}`))
+		case "/api/v1/data-projects/p3":
+			_, _ = w.Write([]byte(`{"key":"p3","info":{"spaceId":"s1"},"dataProject":{"id":"p3","name":"Lake"}}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &qlikcloud.Client{HostURL: server.URL, HTTPClient: server.Client()}
+
+	projects, err := ListDataProjects(client, "s1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(projects) != 2 || projects[0].Key != "p1" || projects[1].Key != "p3" {
+		t.Errorf("got projects %+v, want p1 and p3", projects)
+	}
+}
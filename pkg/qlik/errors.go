@@ -0,0 +1,49 @@
+package qlik
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+)
+
+// Error is returned by the requests in this package when the API responds
+// with an unsuccessful status.
+type Error struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("status: %d, body: %s", e.StatusCode, e.Body)
+}
+
+// clientError matches the errors of the Qlik Cloud client, which only report
+// the status in their message.
+var clientError = regexp.MustCompile(`^status: (\d{3}), body: `)
+
+// StatusCode returns the HTTP status of the failed request behind err, for
+// errors of this package as well as those of the Qlik Cloud client. It returns
+// zero when err did not come from an API response, such as a network error.
+func StatusCode(err error) int {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+
+	for ; err != nil; err = errors.Unwrap(err) {
+		if match := clientError.FindStringSubmatch(err.Error()); match != nil {
+			status, _ := strconv.Atoi(match[1])
+			return status
+		}
+	}
+
+	return 0
+}
+
+// IsNotFound reports whether err is a response to a request for an object
+// that does not exist, such as one that was deleted outside of Terraform.
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
@@ -0,0 +1,49 @@
+package qlik
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestStatusCode(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want int
+	}{
+		"package error": {
+			err:  &Error{StatusCode: 404, Body: `{"errors":[]}`},
+			want: 404,
+		},
+		"wrapped package error": {
+			err:  fmt.Errorf("reading space: %w", &Error{StatusCode: 429}),
+			want: 429,
+		},
+		"client error": {
+			err:  fmt.Errorf("status: %d, body: %s", 404, `{"errors":[{"code":"SPACE-404"}]}`),
+			want: 404,
+		},
+		"wrapped client error": {
+			err:  fmt.Errorf("reading space: %w", fmt.Errorf("status: %d, body: %s", 503, "")),
+			want: 503,
+		},
+		"network error": {
+			err:  errors.New("dial tcp: connection refused"),
+			want: 0,
+		},
+		"status in body only": {
+			err:  errors.New("unexpected response, status: 404, body: none"),
+			want: 0,
+		},
+		"nil": {
+			err:  nil,
+			want: 0,
+		},
+	}
+
+	for name, c := range cases {
+		if got := StatusCode(c.err); got != c.want {
+			t.Errorf("%s: got %d, want %d", name, got, c.want)
+		}
+	}
+}
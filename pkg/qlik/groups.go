@@ -0,0 +1,55 @@
+package qlik
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+)
+
+// Group is a group of users, usually provided by the identity provider.
+type Group struct {
+	ID            string         `json:"id"`
+	Name          string         `json:"name"`
+	Status        string         `json:"status"`
+	AssignedRoles []AssignedRole `json:"assignedRoles"`
+}
+
+// GroupFilter narrows the groups returned by ListGroups. Empty fields are not
+// filtered on.
+type GroupFilter struct {
+	Name   string
+	Status string
+}
+
+// ListGroups returns every group matching filter.
+func ListGroups(c *qlikcloud.Client, filter GroupFilter) ([]Group, error) {
+	var clauses []string
+
+	if filter.Name != "" {
+		clauses = append(clauses, fmt.Sprintf("name eq %s", quoteFilterValue(filter.Name)))
+	}
+
+	if filter.Status != "" {
+		clauses = append(clauses, fmt.Sprintf("status eq %s", quoteFilterValue(filter.Status)))
+	}
+
+	query := url.Values{}
+	if len(clauses) > 0 {
+		query.Set("filter", strings.Join(clauses, " and "))
+	}
+
+	return list[Group](c, "/api/v1/groups", query, 0)
+}
+
+// GetGroup returns the group with the given ID.
+func GetGroup(c *qlikcloud.Client, id string) (*Group, error) {
+	group := Group{}
+	err := doJSON(c, "GET", "/api/v1/groups/"+url.PathEscape(id), nil, &group)
+	if err != nil {
+		return nil, err
+	}
+
+	return &group, nil
+}
@@ -0,0 +1,76 @@
+package qlik
+
+import (
+	"fmt"
+	"net/url"
+
+	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+)
+
+// SpaceAssignment gives a user or group roles in a space.
+type SpaceAssignment struct {
+	ID         string   `json:"id,omitempty"`
+	SpaceID    string   `json:"spaceId,omitempty"`
+	Type       string   `json:"type,omitempty"`
+	AssigneeID string   `json:"assigneeId,omitempty"`
+	Roles      []string `json:"roles"`
+}
+
+// spaceAssignmentsPath returns the path of the assignments of a space.
+func spaceAssignmentsPath(spaceID string) string {
+	return fmt.Sprintf("/api/v1/spaces/%s/assignments", url.PathEscape(spaceID))
+}
+
+// spaceAssignmentPath returns the path of a single assignment of a space.
+func spaceAssignmentPath(spaceID, assignmentID string) string {
+	return fmt.Sprintf("%s/%s", spaceAssignmentsPath(spaceID), url.PathEscape(assignmentID))
+}
+
+// ListSpaceAssignments returns every assignment of the space with the given ID.
+func ListSpaceAssignments(c *qlikcloud.Client, spaceID string) ([]SpaceAssignment, error) {
+	return list[SpaceAssignment](c, spaceAssignmentsPath(spaceID), nil, 0)
+}
+
+// GetSpaceAssignment returns an assignment of a space.
+func GetSpaceAssignment(c *qlikcloud.Client, spaceID, assignmentID string) (*SpaceAssignment, error) {
+	assignment := SpaceAssignment{}
+	err := doJSON(c, "GET", spaceAssignmentPath(spaceID, assignmentID), nil, &assignment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &assignment, nil
+}
+
+// CreateSpaceAssignment assigns the roles of assignment to its user or group
+// in a space.
+func CreateSpaceAssignment(c *qlikcloud.Client, spaceID string, assignment SpaceAssignment) (*SpaceAssignment, error) {
+	created := SpaceAssignment{}
+	err := doJSON(c, "POST", spaceAssignmentsPath(spaceID), SpaceAssignment{
+		Type:       assignment.Type,
+		AssigneeID: assignment.AssigneeID,
+		Roles:      assignment.Roles,
+	}, &created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// UpdateSpaceAssignment replaces the roles of an assignment of a space. The
+// assignee of an assignment cannot be changed.
+func UpdateSpaceAssignment(c *qlikcloud.Client, spaceID, assignmentID string, roles []string) (*SpaceAssignment, error) {
+	updated := SpaceAssignment{}
+	err := doJSON(c, "PUT", spaceAssignmentPath(spaceID, assignmentID), SpaceAssignment{Roles: roles}, &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// DeleteSpaceAssignment removes an assignment from a space.
+func DeleteSpaceAssignment(c *qlikcloud.Client, spaceID, assignmentID string) error {
+	return doJSON(c, "DELETE", spaceAssignmentPath(spaceID, assignmentID), nil, nil)
+}
@@ -0,0 +1,61 @@
+package qlik
+
+import (
+	"net/url"
+
+	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+)
+
+// Space is a space as listed by the spaces API, including the audit
+// timestamps the Qlik Cloud client leaves out.
+type Space struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	OwnerID     string `json:"ownerId"`
+	CreatedAt   string `json:"createdAt"`
+	UpdatedAt   string `json:"updatedAt"`
+}
+
+// SpaceFilter narrows the spaces returned by ListSpaces. Empty fields are not
+// filtered on.
+type SpaceFilter struct {
+	// Name matches spaces whose name contains it.
+	Name    string
+	Type    string
+	OwnerID string
+
+	// Sort orders the spaces by a field, such as "+name" or "-createdAt".
+	Sort string
+}
+
+// ListSpaces returns the spaces matching filter, at most maxResults of them
+// when it is greater than zero.
+func ListSpaces(c *qlikcloud.Client, filter SpaceFilter, maxResults int) ([]Space, error) {
+	query := url.Values{}
+
+	for key, value := range map[string]string{
+		"name":    filter.Name,
+		"type":    filter.Type,
+		"ownerId": filter.OwnerID,
+		"sort":    filter.Sort,
+	} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	return list[Space](c, "/api/v1/spaces", query, maxResults)
+}
+
+// GetSpace returns the space with the given ID.
+func GetSpace(c *qlikcloud.Client, id string) (*Space, error) {
+	space := Space{}
+	err := doJSON(c, "GET", "/api/v1/spaces/"+url.PathEscape(id), nil, &space)
+	if err != nil {
+		return nil, err
+	}
+
+	return &space, nil
+}
@@ -0,0 +1,42 @@
+package qlik
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+)
+
+func TestListSpaces(t *testing.T) {
+	var queries []string
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+
+		if r.URL.Query().Get("next") == "" {
+			_, _ = w.Write([]byte(`{"data":[{"id":"s1"},{"id":"s2"}],"links":{"next":{"href":"` + server.URL + `/api/v1/spaces?next=2"}}}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"data":[{"id":"s3"},{"id":"s4"}],"links":{}}`))
+	}))
+	defer server.Close()
+
+	client := &qlikcloud.Client{HostURL: server.URL, HTTPClient: server.Client()}
+
+	spaces, err := ListSpaces(client, SpaceFilter{Type: "shared", OwnerID: "u1", Sort: "-createdAt"}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(spaces) != 3 || spaces[0].ID != "s1" || spaces[2].ID != "s3" {
+		t.Errorf("got spaces %+v, want s1, s2 and s3", spaces)
+	}
+
+	want := "limit=100&ownerId=u1&sort=-createdAt&type=shared"
+	if queries[0] != want {
+		t.Errorf("got query %q, want %q", queries[0], want)
+	}
+}
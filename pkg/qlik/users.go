@@ -0,0 +1,179 @@
+package qlik
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+)
+
+// User is a user of the tenant.
+type User struct {
+	ID             string         `json:"id"`
+	Subject        string         `json:"subject"`
+	Name           string         `json:"name"`
+	Email          string         `json:"email"`
+	Status         string         `json:"status"`
+	AssignedRoles  []AssignedRole `json:"assignedRoles"`
+	AssignedGroups []Reference    `json:"assignedGroups"`
+}
+
+// AssignedRole is a role assigned to a user or group.
+type AssignedRole struct {
+	ID    string `json:"id,omitempty"`
+	Name  string `json:"name,omitempty"`
+	Type  string `json:"type,omitempty"`
+	Level string `json:"level,omitempty"`
+}
+
+// Reference identifies another object by its ID and name.
+type Reference struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// UserFilter narrows the users returned by ListUsers. Empty fields are not
+// filtered on.
+type UserFilter struct {
+	Email       string
+	EmailPrefix string
+	Name        string
+	Subject     string
+	Status      string
+	Role        string
+}
+
+// ListUsers returns every user matching filter.
+func ListUsers(c *qlikcloud.Client, filter UserFilter) ([]User, error) {
+	var clauses []string
+
+	for _, clause := range []struct {
+		attribute string
+		operator  string
+		value     string
+	}{
+		{"email", "eq", filter.Email},
+		{"email", "sw", filter.EmailPrefix},
+		{"name", "eq", filter.Name},
+		{"subject", "eq", filter.Subject},
+		{"status", "eq", filter.Status},
+		{"assignedRoles.name", "eq", filter.Role},
+	} {
+		if clause.value != "" {
+			clauses = append(clauses, fmt.Sprintf("%s %s %s", clause.attribute, clause.operator, quoteFilterValue(clause.value)))
+		}
+	}
+
+	query := url.Values{}
+	if len(clauses) > 0 {
+		query.Set("filter", strings.Join(clauses, " and "))
+	}
+
+	return list[User](c, "/api/v1/users", query, 0)
+}
+
+// GetUser returns the user with the given ID.
+func GetUser(c *qlikcloud.Client, id string) (*User, error) {
+	user := User{}
+	err := doJSON(c, "GET", "/api/v1/users/"+url.PathEscape(id), nil, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// CreateUser provisions a user with the subject, name, email, status and
+// roles of user. Roles are given by name.
+func CreateUser(c *qlikcloud.Client, user User) (*User, error) {
+	created := User{}
+	err := doJSON(c, "POST", "/api/v1/users", struct {
+		Subject       string         `json:"subject"`
+		Name          string         `json:"name,omitempty"`
+		Email         string         `json:"email,omitempty"`
+		Status        string         `json:"status,omitempty"`
+		AssignedRoles []AssignedRole `json:"assignedRoles,omitempty"`
+	}{
+		Subject:       user.Subject,
+		Name:          user.Name,
+		Email:         user.Email,
+		Status:        user.Status,
+		AssignedRoles: user.AssignedRoles,
+	}, &created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// inviteResult is the outcome of inviting a single user.
+type inviteResult struct {
+	UserID string `json:"userId"`
+	Email  string `json:"email"`
+	Status string `json:"status"`
+	Error  struct {
+		Title  string `json:"title"`
+		Detail string `json:"detail"`
+	} `json:"error"`
+}
+
+// InviteUser invites the user with the given email to the tenant and returns
+// the ID of the new user. The user stays invited until they first sign in.
+func InviteUser(c *qlikcloud.Client, email, name string) (string, error) {
+	type invitee struct {
+		Email string `json:"email"`
+		Name  string `json:"name,omitempty"`
+	}
+
+	response := struct {
+		Data []inviteResult `json:"data"`
+	}{}
+	err := doJSON(c, "POST", "/api/v1/users/actions/invite", struct {
+		Invitees []invitee `json:"invitees"`
+	}{
+		Invitees: []invitee{{Email: email, Name: name}},
+	}, &response)
+	if err != nil {
+		return "", err
+	}
+
+	if len(response.Data) != 1 {
+		return "", fmt.Errorf("expected the result of 1 invite, got %d", len(response.Data))
+	}
+
+	result := response.Data[0]
+	switch {
+	case result.Status == "exists":
+		return "", fmt.Errorf("a user with email %s already exists", email)
+	case result.Status != "ok":
+		return "", fmt.Errorf("inviting %s failed with status %q: %s %s", email, result.Status, result.Error.Title, result.Error.Detail)
+	case result.UserID == "":
+		return "", fmt.Errorf("inviting %s did not return a user ID", email)
+	}
+
+	return result.UserID, nil
+}
+
+// PatchOperation is a single JSON Patch operation on an object.
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// PatchUser applies operations to the user with the given ID.
+func PatchUser(c *qlikcloud.Client, id string, operations []PatchOperation) error {
+	return doJSON(c, "PATCH", "/api/v1/users/"+url.PathEscape(id), operations, nil)
+}
+
+// DeleteUser deletes the user with the given ID.
+func DeleteUser(c *qlikcloud.Client, id string) error {
+	return doJSON(c, "DELETE", "/api/v1/users/"+url.PathEscape(id), nil, nil)
+}
+
+// quoteFilterValue quotes a value for use in a SCIM filter expression.
+func quoteFilterValue(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
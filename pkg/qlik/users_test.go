@@ -0,0 +1,89 @@
+package qlik
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+)
+
+func TestListUsers(t *testing.T) {
+	var filters []string
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filters = append(filters, r.URL.Query().Get("filter"))
+
+		if r.URL.Query().Get("next") == "" {
+			_, _ = w.Write([]byte(`{"data":[{"id":"u1","email":"a@example.com"}],"links":{"next":{"href":"` + server.URL + `/api/v1/users?next=2"}}}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"data":[{"id":"u2","email":"ab@example.com"}],"links":{}}`))
+	}))
+	defer server.Close()
+
+	client := &qlikcloud.Client{HostURL: server.URL, HTTPClient: server.Client()}
+
+	users, err := ListUsers(client, UserFilter{EmailPrefix: "a", Status: "active", Role: `Tenant "Admin"`})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(users) != 2 || users[0].ID != "u1" || users[1].ID != "u2" {
+		t.Errorf("got users %+v, want u1 and u2", users)
+	}
+
+	want := `email sw "a" and status eq "active" and assignedRoles.name eq "Tenant \"Admin\""`
+	if filters[0] != want {
+		t.Errorf("got filter %q, want %q", filters[0], want)
+	}
+}
+
+func TestInviteUser(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		response string
+		wantID   string
+		wantErr  bool
+	}{
+		{
+			name:     "invited",
+			response: `{"data":[{"userId":"u1","email":"a@example.com","status":"ok"}]}`,
+			wantID:   "u1",
+		},
+		{
+			name:     "exists",
+			response: `{"data":[{"email":"a@example.com","status":"exists"}]}`,
+			wantErr:  true,
+		},
+		{
+			name:     "error",
+			response: `{"data":[{"email":"a@example.com","status":"error","error":{"title":"Invalid email"}}]}`,
+			wantErr:  true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" || r.URL.Path != "/api/v1/users/actions/invite" {
+					t.Errorf("got request %s %s", r.Method, r.URL.Path)
+				}
+
+				_, _ = w.Write([]byte(tc.response))
+			}))
+			defer server.Close()
+
+			client := &qlikcloud.Client{HostURL: server.URL, HTTPClient: server.Client()}
+
+			id, err := InviteUser(client, "a@example.com", "A")
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error %v, want error %t", err, tc.wantErr)
+			}
+
+			if id != tc.wantID {
+				t.Errorf("got ID %q, want %q", id, tc.wantID)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package resources
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/daniepett/qlik-cloud-client-go/models"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// connector declares how data connections of a data source type are built.
+// Adding a data source type only takes adding its connector to connectors.
+type connector struct {
+	// driver is the engine driver that serves the connections, stored as
+	// their type.
+	driver string
+
+	// properties build the connect statement of the connections, in the
+	// order they are sent.
+	properties []connectorProperty
+
+	// secrets build the credentials of the connections. The API never
+	// returns them.
+	secrets []connectorProperty
+
+	// required are the connection_parameters attributes that must be set.
+	required []string
+}
+
+// connectorProperty is a property of a connection, with either a fixed value
+// or a value taken from a connection_parameters attribute.
+type connectorProperty struct {
+	name      string
+	value     string
+	parameter string
+}
+
+// fixed returns a property that has the same value for every connection.
+func fixed(name, value string) connectorProperty {
+	return connectorProperty{name: name, value: value}
+}
+
+// parameter returns a property set by a connection_parameters attribute.
+func parameter(name, attribute string) connectorProperty {
+	return connectorProperty{name: name, parameter: attribute}
+}
+
+// connectors are the data source types qlik_data_connection can create,
+// keyed by data source ID.
+var connectors = map[string]connector{
+	"reptgt_qdisnowflake": {
+		driver: "QlikConnectorsCommonService.exe",
+		properties: []connectorProperty{
+			fixed("endpointTypePrefix", "reptgt_"),
+			fixed("useDbCommandForTest", "true"),
+			fixed("replicateEndpointType", "snowflake"),
+			parameter("server", "server"),
+			fixed("port", "443"),
+			parameter("username", "username"),
+			parameter("warehouse", "warehouse"),
+			parameter("database", "database"),
+			parameter("metadataschema", "metadata_schema"),
+			fixed("stagingtype", "SNOWFLAKE_STAGE"),
+			fixed("proxySettingsOrigin", "ENDPOINT"),
+			fixed("useProxyServer", "false"),
+		},
+		secrets: []connectorProperty{
+			parameter("password", "password"),
+		},
+		required: []string{"server", "username", "warehouse", "database", "password"},
+	},
+}
+
+// connectorTypes returns the data source IDs that have a connector, sorted.
+func connectorTypes() []string {
+	ids := make([]string, 0, len(connectors))
+	for dataSourceID := range connectors {
+		ids = append(ids, dataSourceID)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+// connectionStrings returns the properties and credentials sent to build the
+// connection string of a connection of the data source type. Every connector
+// is reached through a data gateway, so the source type and the gateway come
+// first.
+func (c connector) connectionStrings(dataSourceID, gatewayID string, params *DataConnectionParameters) (models.GetConnectionString, models.GetConnectionString) {
+	values := params.attributes()
+
+	build := func(properties []connectorProperty) []models.ConnectionProperties {
+		list := make([]models.ConnectionProperties, 0, len(properties))
+		for _, p := range properties {
+			value := p.value
+			if p.parameter != "" {
+				value = values[p.parameter].ValueString()
+			}
+
+			list = append(list, models.ConnectionProperties{Name: p.name, Value: value})
+		}
+
+		return list
+	}
+
+	properties := append([]connectorProperty{
+		fixed("sourceType", dataSourceID),
+		fixed("agentId", gatewayID),
+	}, c.properties...)
+
+	return models.GetConnectionString{PropertiesList: build(properties)},
+		models.GetConnectionString{PropertiesList: build(c.secrets)}
+}
+
+// parameters returns the connection_parameters attributes the connector
+// uses, in the order they are declared, with the secret ones last.
+func (c connector) parameters() []ConnectionParameter {
+	var parameters []ConnectionParameter
+	for _, p := range c.properties {
+		if p.parameter != "" {
+			parameters = append(parameters, ConnectionParameter{Attribute: p.parameter, Property: strings.ToLower(p.name)})
+		}
+	}
+
+	for _, p := range c.secrets {
+		parameters = append(parameters, ConnectionParameter{Attribute: p.parameter, Property: strings.ToLower(p.name), Secret: true})
+	}
+
+	return parameters
+}
+
+// ConnectionParameter is a connection_parameters attribute of
+// qlik_data_connection used by the connector of a data source type.
+type ConnectionParameter struct {
+	// Attribute is the name of the attribute.
+	Attribute string
+
+	// Property is the connect statement property the attribute sets, in
+	// lower case as returned by qlik.ParseConnectStatement.
+	Property string
+
+	// Secret attributes are never returned by the API.
+	Secret bool
+}
+
+// ConnectionParameters returns the connection_parameters attributes used by
+// connections of the given data source type, in the order they are
+// declared. It returns false when qlik_data_connection cannot create
+// connections of the type.
+func ConnectionParameters(dataSourceID string) ([]ConnectionParameter, bool) {
+	c, ok := connectors[dataSourceID]
+	if !ok {
+		return nil, false
+	}
+
+	return c.parameters(), true
+}
+
+// attributes returns the connection_parameters attributes by name.
+func (p *DataConnectionParameters) attributes() map[string]*types.String {
+	return map[string]*types.String{
+		"server":          &p.Server,
+		"username":        &p.Username,
+		"warehouse":       &p.Warehouse,
+		"database":        &p.Database,
+		"metadata_schema": &p.MetadataSchema,
+		"sap_client":      &p.SapClient,
+		"password":        &p.Password,
+	}
+}
@@ -0,0 +1,92 @@
+package resources
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/daniepett/qlik-cloud-client-go/models"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestConnectorConnectionStrings(t *testing.T) {
+	params := &DataConnectionParameters{
+		Server:         types.StringValue("acme.snowflakecomputing.com"),
+		Username:       types.StringValue("loader"),
+		Warehouse:      types.StringValue("LOAD_WH"),
+		Database:       types.StringValue("RAW"),
+		MetadataSchema: types.StringNull(),
+		Password:       types.StringValue("secret"),
+	}
+
+	conn, crd := connectors["reptgt_qdisnowflake"].connectionStrings("reptgt_qdisnowflake", "gateway-1", params)
+
+	wantConn := []models.ConnectionProperties{
+		{Name: "sourceType", Value: "reptgt_qdisnowflake"},
+		{Name: "agentId", Value: "gateway-1"},
+		{Name: "endpointTypePrefix", Value: "reptgt_"},
+		{Name: "useDbCommandForTest", Value: "true"},
+		{Name: "replicateEndpointType", Value: "snowflake"},
+		{Name: "server", Value: "acme.snowflakecomputing.com"},
+		{Name: "port", Value: "443"},
+		{Name: "username", Value: "loader"},
+		{Name: "warehouse", Value: "LOAD_WH"},
+		{Name: "database", Value: "RAW"},
+		{Name: "metadataschema", Value: ""},
+		{Name: "stagingtype", Value: "SNOWFLAKE_STAGE"},
+		{Name: "proxySettingsOrigin", Value: "ENDPOINT"},
+		{Name: "useProxyServer", Value: "false"},
+	}
+	if !reflect.DeepEqual(conn.PropertiesList, wantConn) {
+		t.Errorf("got properties %+v, want %+v", conn.PropertiesList, wantConn)
+	}
+
+	wantCrd := []models.ConnectionProperties{
+		{Name: "password", Value: "secret"},
+	}
+	if !reflect.DeepEqual(crd.PropertiesList, wantCrd) {
+		t.Errorf("got credentials %+v, want %+v", crd.PropertiesList, wantCrd)
+	}
+}
+
+func TestConnectors(t *testing.T) {
+	attributes := (&DataConnectionParameters{}).attributes()
+
+	for dataSourceID, c := range connectors {
+		used := map[string]bool{}
+		for _, p := range c.parameters() {
+			if _, ok := attributes[p.Attribute]; !ok {
+				t.Errorf("%s: parameter %s is not a connection_parameters attribute", dataSourceID, p.Attribute)
+			}
+			used[p.Attribute] = true
+		}
+
+		for _, attribute := range c.required {
+			if !used[attribute] {
+				t.Errorf("%s: required attribute %s is not used by any property", dataSourceID, attribute)
+			}
+		}
+	}
+}
+
+func TestConnectionParameters(t *testing.T) {
+	parameters, ok := ConnectionParameters("reptgt_qdisnowflake")
+	if !ok {
+		t.Fatal("got no connector for reptgt_qdisnowflake")
+	}
+
+	want := []ConnectionParameter{
+		{Attribute: "server", Property: "server"},
+		{Attribute: "username", Property: "username"},
+		{Attribute: "warehouse", Property: "warehouse"},
+		{Attribute: "database", Property: "database"},
+		{Attribute: "metadata_schema", Property: "metadataschema"},
+		{Attribute: "password", Property: "password", Secret: true},
+	}
+	if !reflect.DeepEqual(parameters, want) {
+		t.Errorf("got parameters %+v, want %+v", parameters, want)
+	}
+
+	if _, ok := ConnectionParameters("SAP_APPLICATION"); ok {
+		t.Error("got a connector for SAP_APPLICATION, which has none")
+	}
+}
@@ -3,21 +3,27 @@ package resources
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
 	"github.com/daniepett/qlik-cloud-client-go/models"
+	"github.com/daniepett/terraform-provider-qlik/pkg/qlik"
+	"github.com/daniepett/terraform-provider-qlik/pkg/transport"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &DataAppResource{}
-	_ resource.ResourceWithConfigure = &DataAppResource{}
-	// _ resource.ResourceWithImportState = &spaceResource{}
+	_ resource.Resource                = &DataAppResource{}
+	_ resource.ResourceWithConfigure   = &DataAppResource{}
+	_ resource.ResourceWithImportState = &DataAppResource{}
 )
 
 // NewOrderResource is a helper function to simplify the provider implementation.
@@ -32,11 +38,12 @@ type DataAppResource struct {
 
 // orderResourceModel maps the resource schema data.
 type DataAppResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Type        types.String `tfsdk:"type"`
-	Description types.String `tfsdk:"description"`
-	ProjectID   types.String `tfsdk:"project_id"`
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	Type        types.String   `tfsdk:"type"`
+	Description types.String   `tfsdk:"description"`
+	ProjectID   types.String   `tfsdk:"project_id"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata returns the resource type name.
@@ -45,7 +52,7 @@ func (r *DataAppResource) Metadata(_ context.Context, req resource.MetadataReque
 }
 
 // Schema defines the schema for the resource.
-func (r *DataAppResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *DataAppResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -67,6 +74,14 @@ func (r *DataAppResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Required: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -100,6 +115,15 @@ func (r *DataAppResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	a := models.DataAppCreate{
 		Data: models.DataAppCreateData{
 			Name:        plan.Name.ValueString(),
@@ -109,8 +133,11 @@ func (r *DataAppResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	// Create new space
-	app, err := r.client.CreateDataApp(plan.ProjectID.ValueString(), a)
+	app, err := transport.WithContext(ctx, r.client).CreateDataApp(plan.ProjectID.ValueString(), a)
 	if err != nil {
+		if deadlineExceeded(ctx, &resp.Diagnostics, "create", createTimeout, err) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error creating data connection",
 			"Could not create order, unexpected error: "+err.Error(),
@@ -139,8 +166,25 @@ func (r *DataAppResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	a, err := r.client.GetDataApp(state.ProjectID.ValueString(), state.ID.ValueString())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	a, err := transport.WithContext(ctx, r.client).GetDataApp(state.ProjectID.ValueString(), state.ID.ValueString())
 	if err != nil {
+		if qlik.IsNotFound(err) {
+			tflog.Warn(ctx, "Data app not found, removing it from state", map[string]interface{}{"id": state.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		if deadlineExceeded(ctx, &resp.Diagnostics, "read", readTimeout, err) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Reading Qlik Cloud Data App",
 			"Could not read App ID "+state.ID.ValueString()+": "+err.Error(),
@@ -149,7 +193,8 @@ func (r *DataAppResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 
 	state.Name = types.StringValue(a.DataApp.Name)
-	state.Description = types.StringValue(a.DataApp.Description)
+	state.Type = types.StringValue(a.DataApp.Type)
+	state.Description = optionalString(a.DataApp.Description)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -168,6 +213,15 @@ func (r *DataAppResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	project := models.DataAppUpdate{
 		Data: models.DataAppUpdateData{
 			Name:        plan.Name.ValueString(),
@@ -177,8 +231,11 @@ func (r *DataAppResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	// Create new space
-	a, err := r.client.UpdateDataApp(plan.ProjectID.ValueString(), project)
+	a, err := transport.WithContext(ctx, r.client).UpdateDataApp(plan.ProjectID.ValueString(), project)
 	if err != nil {
+		if deadlineExceeded(ctx, &resp.Diagnostics, "update", updateTimeout, err) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error updating data app",
 			"Could not update data app, unexpected error: "+err.Error(),
@@ -205,10 +262,26 @@ func (r *DataAppResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Delete existing order
-	err := r.client.DeleteDataApp(state.ProjectID.ValueString(), state.ID.ValueString())
+	err := transport.WithContext(ctx, r.client).DeleteDataApp(state.ProjectID.ValueString(), state.ID.ValueString())
 
 	if err != nil {
+		// Objects deleted outside of Terraform are already gone.
+		if qlik.IsNotFound(err) {
+			return
+		}
+		if deadlineExceeded(ctx, &resp.Diagnostics, "delete", deleteTimeout, err) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Deleting Data App",
 			"Could not delete Data App, unexpected error: "+err.Error(),
@@ -217,7 +290,18 @@ func (r *DataAppResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 }
 
-// func (r *orderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-// 	// Retrieve import ID and save to id attribute
-// 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
-// }
+// ImportState imports a data app by the ID of its data project and its own
+// ID separated by a slash, as both are needed to read it.
+func (r *DataAppResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, appID, found := strings.Cut(req.ID, "/")
+	if !found || projectID == "" || appID == "" || strings.Contains(appID, "/") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected an import identifier of the form <project_id>/<app_id>, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), appID)...)
+}
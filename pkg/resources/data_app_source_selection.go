@@ -3,21 +3,27 @@ package resources
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
 	"github.com/daniepett/qlik-cloud-client-go/models"
+	"github.com/daniepett/terraform-provider-qlik/pkg/qlik"
+	"github.com/daniepett/terraform-provider-qlik/pkg/transport"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &DataAppSourceSelectionResource{}
-	_ resource.ResourceWithConfigure = &DataAppSourceSelectionResource{}
-	// _ resource.ResourceWithImportState = &spaceResource{}
+	_ resource.Resource                = &DataAppSourceSelectionResource{}
+	_ resource.ResourceWithConfigure   = &DataAppSourceSelectionResource{}
+	_ resource.ResourceWithImportState = &DataAppSourceSelectionResource{}
 )
 
 // NewOrderResource is a helper function to simplify the provider implementation.
@@ -37,6 +43,7 @@ type DataAppSourceSelectionResourceModel struct {
 	AppID              types.String           `tfsdk:"app_id"`
 	SourceConnectionID types.String           `tfsdk:"source_connection_id"`
 	SourceSelection    []SourceSelectionModel `tfsdk:"source_selection"`
+	Timeouts           timeouts.Value         `tfsdk:"timeouts"`
 }
 
 type SourceSelectionModel struct {
@@ -55,7 +62,7 @@ func (r *DataAppSourceSelectionResource) Metadata(_ context.Context, req resourc
 }
 
 // Schema defines the schema for the resource.
-func (r *DataAppSourceSelectionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *DataAppSourceSelectionResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -102,6 +109,13 @@ func (r *DataAppSourceSelectionResource) Schema(_ context.Context, _ resource.Sc
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+			}),
+		},
 	}
 }
 
@@ -135,6 +149,15 @@ func (r *DataAppSourceSelectionResource) Create(ctx context.Context, req resourc
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	a := models.SourceSelectionPut{
 		Data: models.SourceSelectionPutData{
 			DataEntitiesSelection: models.SourceSelectionDataEntitiesSelection{
@@ -158,8 +181,11 @@ func (r *DataAppSourceSelectionResource) Create(ctx context.Context, req resourc
 	}
 
 	// Create new space
-	s, err := r.client.PutSourceSelection(plan.ProjectID.ValueString(), plan.AppID.ValueString(), a)
+	s, err := transport.WithContext(ctx, r.client).PutSourceSelection(plan.ProjectID.ValueString(), plan.AppID.ValueString(), a)
 	if err != nil {
+		if deadlineExceeded(ctx, &resp.Diagnostics, "create", createTimeout, err) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error creating data connection",
 			"Could not create order, unexpected error: "+err.Error(),
@@ -188,9 +214,26 @@ func (r *DataAppSourceSelectionResource) Read(ctx context.Context, req resource.
 		return
 	}
 
-	s, err := r.client.GetSourceSelection(state.ProjectID.ValueString(), state.AppID.ValueString())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	s, err := transport.WithContext(ctx, r.client).GetSourceSelection(state.ProjectID.ValueString(), state.AppID.ValueString())
 
 	if err != nil {
+		if qlik.IsNotFound(err) {
+			tflog.Warn(ctx, "Data app source selection not found, removing it from state", map[string]interface{}{"app_id": state.AppID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		if deadlineExceeded(ctx, &resp.Diagnostics, "read", readTimeout, err) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Reading Data App Source Selection",
 			"Could not read Data App Source Selection"+state.ID.ValueString()+": "+err.Error(),
@@ -198,7 +241,8 @@ func (r *DataAppSourceSelectionResource) Read(ctx context.Context, req resource.
 		return
 	}
 
-	// Map response body to model
+	// Map response body to model, replacing the previous selection
+	state.SourceSelection = nil
 	for _, source := range s.SourceSelection.DataEntitiesSelection.DataEntities {
 		sourceState := SourceSelectionModel{
 			ID:        types.StringValue(source.ID),
@@ -232,6 +276,15 @@ func (r *DataAppSourceSelectionResource) Update(ctx context.Context, req resourc
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	a := models.SourceSelectionPut{
 		Data: models.SourceSelectionPutData{
 			DataEntitiesSelection: models.SourceSelectionDataEntitiesSelection{
@@ -255,8 +308,11 @@ func (r *DataAppSourceSelectionResource) Update(ctx context.Context, req resourc
 	}
 
 	// Create new space
-	_, err := r.client.PutSourceSelection(plan.ProjectID.ValueString(), plan.AppID.ValueString(), a)
+	_, err := transport.WithContext(ctx, r.client).PutSourceSelection(plan.ProjectID.ValueString(), plan.AppID.ValueString(), a)
 	if err != nil {
+		if deadlineExceeded(ctx, &resp.Diagnostics, "update", updateTimeout, err) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error creating data connection",
 			"Could not create order, unexpected error: "+err.Error(),
@@ -292,7 +348,18 @@ func (r *DataAppSourceSelectionResource) Delete(ctx context.Context, req resourc
 	// }
 }
 
-// func (r *orderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-// 	// Retrieve import ID and save to id attribute
-// 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
-// }
+// ImportState imports the source selection of a data app by the ID of its
+// data project and the ID of the data app separated by a slash.
+func (r *DataAppSourceSelectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, appID, found := strings.Cut(req.ID, "/")
+	if !found || projectID == "" || appID == "" || strings.Contains(appID, "/") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected an import identifier of the form <project_id>/<app_id>, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_id"), appID)...)
+}
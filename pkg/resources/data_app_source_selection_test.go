@@ -0,0 +1,51 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDataAppSourceSelectionImportState(t *testing.T) {
+	ctx := context.Background()
+	r := &DataAppSourceSelectionResource{}
+
+	var schema resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schema)
+
+	importState := func(id string) *resource.ImportStateResponse {
+		resp := &resource.ImportStateResponse{
+			State: tfsdk.State{
+				Schema: schema.Schema,
+				Raw:    tftypes.NewValue(schema.Schema.Type().TerraformType(ctx), nil),
+			},
+		}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: id}, resp)
+
+		return resp
+	}
+
+	resp := importState("p1/a1")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("got diagnostics %v", resp.Diagnostics)
+	}
+
+	var projectID, appID types.String
+	resp.State.GetAttribute(ctx, path.Root("project_id"), &projectID)
+	resp.State.GetAttribute(ctx, path.Root("app_id"), &appID)
+	if projectID.ValueString() != "p1" || appID.ValueString() != "a1" {
+		t.Errorf("got project %s and app %s, want p1 and a1", projectID, appID)
+	}
+
+	for _, id := range []string{"p1", "/a1", "p1/", "p1/a1/x"} {
+		resp := importState(id)
+		if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Unexpected Import Identifier" {
+			t.Errorf("import of %q got diagnostics %v, want an unexpected import identifier error", id, resp.Diagnostics)
+		}
+	}
+}
@@ -2,24 +2,43 @@ package resources
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
 	"github.com/daniepett/qlik-cloud-client-go/models"
+	"github.com/daniepett/terraform-provider-qlik/pkg/qlik"
+	"github.com/daniepett/terraform-provider-qlik/pkg/transport"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &DataConnectionResource{}
-	_ resource.ResourceWithConfigure = &DataConnectionResource{}
-	// _ resource.ResourceWithImportState = &spaceResource{}
+	_ resource.Resource                   = &DataConnectionResource{}
+	_ resource.ResourceWithConfigure      = &DataConnectionResource{}
+	_ resource.ResourceWithImportState    = &DataConnectionResource{}
+	_ resource.ResourceWithValidateConfig = &DataConnectionResource{}
 )
 
+// gatewayPollInterval is how long to wait between checks of a data gateway
+// that is not connected yet.
+var gatewayPollInterval = 10 * time.Second
+
 // NewOrderResource is a helper function to simplify the provider implementation.
 func NewDataConnectionResource() resource.Resource {
 	return &DataConnectionResource{}
@@ -32,17 +51,19 @@ type DataConnectionResource struct {
 
 // orderResourceModel maps the resource schema data.
 type DataConnectionResourceModel struct {
-	ID                   types.String             `tfsdk:"id"`
-	Name                 types.String             `tfsdk:"name"`
-	SpaceID              types.String             `tfsdk:"space_id"`
-	GatewayID            types.String             `tfsdk:"gateway_id"`
-	ConnectionParameters DataConnectionParameters `tfsdk:"connection_parameters"`
-	Type                 types.String             `tfsdk:"type"`
-	Driver               types.String             `tfsdk:"driver"`
-	EngineID             types.String             `tfsdk:"engine_id"`
-	ConnectStatement     types.String             `tfsdk:"connect_statement"`
-	CredentialsID        types.String             `tfsdk:"credentials_id"`
-	CredentialsName      types.String             `tfsdk:"credentials_name"`
+	ID                   types.String              `tfsdk:"id"`
+	Name                 types.String              `tfsdk:"name"`
+	SpaceID              types.String              `tfsdk:"space_id"`
+	GatewayID            types.String              `tfsdk:"gateway_id"`
+	WaitForGateway       types.Bool                `tfsdk:"wait_for_gateway"`
+	ConnectionParameters *DataConnectionParameters `tfsdk:"connection_parameters"`
+	Type                 types.String              `tfsdk:"type"`
+	Driver               types.String              `tfsdk:"driver"`
+	EngineID             types.String              `tfsdk:"engine_id"`
+	ConnectStatement     types.String              `tfsdk:"connect_statement"`
+	CredentialsID        types.String              `tfsdk:"credentials_id"`
+	CredentialsName      types.String              `tfsdk:"credentials_name"`
+	Timeouts             timeouts.Value            `tfsdk:"timeouts"`
 }
 
 type DataConnectionParameters struct {
@@ -61,7 +82,7 @@ func (r *DataConnectionResource) Metadata(_ context.Context, req resource.Metada
 }
 
 // Schema defines the schema for the resource.
-func (r *DataConnectionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *DataConnectionResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -79,8 +100,16 @@ func (r *DataConnectionResource) Schema(_ context.Context, _ resource.SchemaRequ
 			"gateway_id": schema.StringAttribute{
 				Required: true,
 			},
+			"wait_for_gateway": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
 			"type": schema.StringAttribute{
 				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(connectorTypes()...),
+				},
 			},
 			"driver": schema.StringAttribute{
 				Computed: true,
@@ -134,6 +163,14 @@ func (r *DataConnectionResource) Schema(_ context.Context, _ resource.SchemaRequ
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -157,6 +194,68 @@ func (r *DataConnectionResource) Configure(_ context.Context, req resource.Confi
 	r.client = client
 }
 
+// ValidateConfig checks the connection_parameters against the connector of
+// the type, which sets the parameters that are required and those that can
+// be used at all.
+func (r *DataConnectionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var src types.String
+	var object types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &src)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("connection_parameters"), &object)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if src.IsUnknown() || src.IsNull() || object.IsUnknown() || object.IsNull() {
+		return
+	}
+
+	// Types without a connector are reported by the validator of type.
+	c, ok := connectors[src.ValueString()]
+	if !ok {
+		return
+	}
+
+	var params DataConnectionParameters
+	resp.Diagnostics.Append(object.As(ctx, &params, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	attributes := params.attributes()
+
+	for _, attribute := range c.required {
+		if attributes[attribute].IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("connection_parameters").AtName(attribute),
+				"Missing Connection Parameter",
+				fmt.Sprintf("Data connections of type %s require connection_parameters.%s.", src.ValueString(), attribute),
+			)
+		}
+	}
+
+	used := map[string]bool{}
+	for _, p := range c.parameters() {
+		used[p.Attribute] = true
+	}
+
+	names := make([]string, 0, len(attributes))
+	for attribute := range attributes {
+		names = append(names, attribute)
+	}
+	sort.Strings(names)
+
+	for _, attribute := range names {
+		if !used[attribute] && !attributes[attribute].IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("connection_parameters").AtName(attribute),
+				"Unsupported Connection Parameter",
+				fmt.Sprintf("Data connections of type %s do not use connection_parameters.%s.", src.ValueString(), attribute),
+			)
+		}
+	}
+}
+
 // Create a new resource.
 func (r *DataConnectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan
@@ -167,17 +266,31 @@ func (r *DataConnectionResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
-	var driver string
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	if plan.Type.ValueString() == "reptgt_qdisnowflake" {
-		driver = "QlikConnectorsCommonService.exe"
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
-	} else if plan.Type.ValueString() == "SAP_APPLICATION" {
-		driver = "QlikConnectorsCommonService.exe"
+	if plan.WaitForGateway.ValueBool() && !r.waitForGateway(ctx, &resp.Diagnostics, "create", createTimeout, plan.GatewayID.ValueString()) {
+		return
 	}
 
 	src := plan.Type.ValueString()
-	c, err := r.GetConnectionString(src, plan)
+	c, err := r.GetConnectionString(ctx, src, plan)
+	if err != nil {
+		if deadlineExceeded(ctx, &resp.Diagnostics, "create", createTimeout, err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error creating data connection",
+			"Could not build the connection string, unexpected error: "+err.Error(),
+		)
+		return
+	}
 
 	newDataConnection := models.ConnectionCreate{
 		Name:             plan.Name.ValueString(),
@@ -185,14 +298,17 @@ func (r *DataConnectionResource) Create(ctx context.Context, req resource.Create
 		LogOn:            1,
 		ConnectStatement: c.ConnectionString,
 		DataSourceID:     src,
-		Type:             driver,
+		Type:             connectors[src].driver,
 		Username:         c.UserID,
 		Password:         c.CredentialsConnectionString,
 	}
 
 	// Create new space
-	DataConnection, err := r.client.CreateDataConnection(newDataConnection)
+	DataConnection, err := transport.WithContext(ctx, r.client).CreateDataConnection(newDataConnection)
 	if err != nil {
+		if deadlineExceeded(ctx, &resp.Diagnostics, "create", createTimeout, err) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error creating data connection",
 			"Could not create order, unexpected error: "+err.Error(),
@@ -226,8 +342,25 @@ func (r *DataConnectionResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	connection, err := r.client.GetDataConnection(state.ID.ValueString())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	connection, err := transport.WithContext(ctx, r.client).GetDataConnection(state.ID.ValueString())
 	if err != nil {
+		if qlik.IsNotFound(err) {
+			tflog.Warn(ctx, "Data connection not found, removing it from state", map[string]interface{}{"id": state.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		if deadlineExceeded(ctx, &resp.Diagnostics, "read", readTimeout, err) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Reading Data Connection",
 			"Could not read Connection ID "+state.ID.ValueString()+": "+err.Error(),
@@ -236,6 +369,45 @@ func (r *DataConnectionResource) Read(ctx context.Context, req resource.ReadRequ
 	}
 
 	state.Name = types.StringValue(connection.Name)
+	state.SpaceID = types.StringValue(connection.SpaceID)
+	state.Type = types.StringValue(connection.DataSourceID)
+	state.Driver = types.StringValue(connection.Type)
+	state.EngineID = types.StringValue(connection.EngineObjectID)
+	state.ConnectStatement = types.StringValue(connection.ConnectStatement)
+	state.CredentialsID = types.StringValue(connection.CredentialsID)
+	state.CredentialsName = types.StringValue(connection.CredentialsName)
+
+	// The connect statement holds the properties the connection was created
+	// with, apart from the secret ones. The password can only come from the
+	// configuration, so it is left as it is.
+	properties := qlik.ParseConnectStatement(connection.ConnectStatement)
+
+	if gatewayID, ok := properties["agentid"]; ok {
+		state.GatewayID = types.StringValue(gatewayID)
+	}
+
+	if state.ConnectionParameters == nil {
+		state.ConnectionParameters = &DataConnectionParameters{}
+	}
+
+	// Parameters that were changed or cleared outside of Terraform show up as
+	// drift. Empty values are left as configured, since a parameter set to an
+	// empty string and one left out end up the same in the statement.
+	attributes := state.ConnectionParameters.attributes()
+	for _, p := range connectors[connection.DataSourceID].parameters() {
+		if p.Secret {
+			continue
+		}
+
+		attribute := attributes[p.Attribute]
+		value := properties[p.Property]
+		if value == "" && attribute.ValueString() == "" {
+			continue
+		}
+
+		*attribute = optionalString(value)
+	}
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -256,17 +428,32 @@ func (r *DataConnectionResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
-	var driver string
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	if plan.Type.ValueString() == "reptgt_qdisnowflake" {
-		driver = "QlikConnectorsCommonService.exe"
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
-	} else if plan.Type.ValueString() == "SAP_APPLICATION" {
-		driver = "QlikConnectorsCommonService.exe"
+	if plan.WaitForGateway.ValueBool() && !r.waitForGateway(ctx, &resp.Diagnostics, "update", updateTimeout, plan.GatewayID.ValueString()) {
+		return
 	}
 
 	src := plan.Type.ValueString()
-	c, err := r.GetConnectionString(src, plan)
+	c, err := r.GetConnectionString(ctx, src, plan)
+	if err != nil {
+		if deadlineExceeded(ctx, &resp.Diagnostics, "update", updateTimeout, err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error updating data connection",
+			"Could not build the connection string, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
 	updateDataConnection := models.ConnectionUpdate{
 		ID:               plan.ID.ValueString(),
 		SpaceID:          plan.SpaceID.ValueString(),
@@ -274,13 +461,16 @@ func (r *DataConnectionResource) Update(ctx context.Context, req resource.Update
 		EngineID:         plan.EngineID.ValueString(),
 		ConnectStatement: c.ConnectionString,
 		DataSourceID:     src,
-		Type:             driver,
+		Type:             connectors[src].driver,
 		Username:         c.UserID,
 		Password:         c.CredentialsConnectionString,
 	}
 	// Create new space
-	err = r.client.UpdateDataConnection(plan.ID.ValueString(), updateDataConnection)
+	err = transport.WithContext(ctx, r.client).UpdateDataConnection(plan.ID.ValueString(), updateDataConnection)
 	if err != nil {
+		if deadlineExceeded(ctx, &resp.Diagnostics, "update", updateTimeout, err) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error updating data connection",
 			"Could not update data connection, unexpected error: "+err.Error(),
@@ -307,10 +497,26 @@ func (r *DataConnectionResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Delete existing order
-	err := r.client.DeleteDataConnection(state.ID.ValueString())
+	err := transport.WithContext(ctx, r.client).DeleteDataConnection(state.ID.ValueString())
 
 	if err != nil {
+		// Objects deleted outside of Terraform are already gone.
+		if qlik.IsNotFound(err) {
+			return
+		}
+		if deadlineExceeded(ctx, &resp.Diagnostics, "delete", deleteTimeout, err) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Deleting Data Connection",
 			"Could not delete Data Connection, unexpected error: "+err.Error(),
@@ -319,90 +525,128 @@ func (r *DataConnectionResource) Delete(ctx context.Context, req resource.Delete
 	}
 }
 
-// func (r *orderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-// 	// Retrieve import ID and save to id attribute
-// 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
-// }
-
-func (r *DataConnectionResource) GetConnectionString(src string, props DataConnectionResourceModel) (*models.GetConnectionStringResponse, error) {
-
-	var conn models.GetConnectionString
-	var crd models.GetConnectionString
-	if src == "reptgt_qdisnowflake" {
-		conn = models.GetConnectionString{
-			PropertiesList: []models.ConnectionProperties{
-				models.ConnectionProperties{
-					Name:  "sourceType",
-					Value: "reptgt_qdisnowflake",
-				},
-				models.ConnectionProperties{
-					Name:  "agentId",
-					Value: props.GatewayID.ValueString(),
-				},
-				models.ConnectionProperties{
-					Name:  "endpointTypePrefix",
-					Value: "reptgt_",
-				},
-				models.ConnectionProperties{
-					Name:  "useDbCommandForTest",
-					Value: "true",
-				},
-				models.ConnectionProperties{
-					Name:  "replicateEndpointType",
-					Value: "snowflake",
-				},
-				models.ConnectionProperties{
-					Name:  "server",
-					Value: props.ConnectionParameters.Server.ValueString(),
-				},
-				models.ConnectionProperties{
-					Name:  "port",
-					Value: "443",
-				},
-				models.ConnectionProperties{
-					Name:  "username",
-					Value: props.ConnectionParameters.Username.ValueString(),
-				},
-				models.ConnectionProperties{
-					Name:  "warehouse",
-					Value: props.ConnectionParameters.Warehouse.ValueString(),
-				},
-				models.ConnectionProperties{
-					Name:  "database",
-					Value: props.ConnectionParameters.Database.ValueString(),
-				},
-				models.ConnectionProperties{
-					Name:  "metadataschema",
-					Value: props.ConnectionParameters.MetadataSchema.ValueString(),
-				},
-				models.ConnectionProperties{
-					Name:  "stagingtype",
-					Value: "SNOWFLAKE_STAGE",
-				},
-				models.ConnectionProperties{
-					Name:  "proxySettingsOrigin",
-					Value: "ENDPOINT",
-				},
-				models.ConnectionProperties{
-					Name:  "useProxyServer",
-					Value: "false",
-				},
-			},
+// ImportState imports a data connection by its ID, or by the ID of its space
+// and its name separated by a slash.
+func (r *DataConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_gateway"), false)...)
+
+	spaceID, name, found := strings.Cut(req.ID, "/")
+	if !found {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	if spaceID == "" || name == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected an import identifier of the form <connection_id> or <space_id>/<name>, got: %q", req.ID),
+		)
+		return
+	}
+
+	connections, err := qlik.ListDataConnections(transport.WithContext(ctx, r.client), qlik.DataConnectionFilter{
+		SpaceID: spaceID,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Data Connection",
+			"Could not list the data connections of space "+spaceID+": "+err.Error(),
+		)
+		return
+	}
+
+	var ids []string
+	for _, connection := range connections {
+		if connection.Name == name {
+			ids = append(ids, connection.ID)
 		}
-		crd = models.GetConnectionString{
-			PropertiesList: []models.ConnectionProperties{
-				models.ConnectionProperties{
-					Name:  "password",
-					Value: props.ConnectionParameters.Password.ValueString(),
-				},
-			},
+	}
+
+	if len(ids) != 1 {
+		resp.Diagnostics.AddError(
+			"Error Importing Data Connection",
+			fmt.Sprintf("Found %d data connections named %q in space %s, expected exactly one.", len(ids), name, spaceID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), ids[0])...)
+}
+
+// waitForGateway polls the data gateway with the given ID until it is
+// connected. It adds an error diagnostic naming the gateway and its state and
+// returns false when the gateway cannot be read or does not connect before
+// the operation times out.
+func (r *DataConnectionResource) waitForGateway(ctx context.Context, diags *diag.Diagnostics, operation string, timeout time.Duration, gatewayID string) bool {
+	gateway, err := pollDataGateway(ctx, r.client, gatewayID)
+	if err == nil {
+		return true
+	}
+
+	if gateway != nil && errors.Is(err, context.DeadlineExceeded) {
+		diags.AddAttributeError(
+			path.Root("gateway_id"),
+			"Data Gateway Not Connected",
+			fmt.Sprintf("Data gateway %q (%s) is %s and did not connect within the %s timeout of the %s operation. "+
+				"Check that the gateway is running and registered with the tenant, or increase the %s value in the timeouts block of the resource.",
+				gateway.Name, gateway.ID, gateway.Status(), timeout, operation, operation),
+		)
+		return false
+	}
+
+	if deadlineExceeded(ctx, diags, operation, timeout, err) {
+		return false
+	}
+
+	diags.AddAttributeError(
+		path.Root("gateway_id"),
+		"Error Reading Data Gateway",
+		"Could not read Data Gateway ID "+gatewayID+": "+err.Error(),
+	)
+	return false
+}
+
+// pollDataGateway reads the data gateway with the given ID until it is
+// connected or ctx is done, returning the gateway as it was last read.
+func pollDataGateway(ctx context.Context, client *qlikcloud.Client, gatewayID string) (*qlik.DataGateway, error) {
+	var last *qlik.DataGateway
+	for {
+		gateway, err := qlik.GetDataGateway(transport.WithContext(ctx, client), gatewayID)
+		if err != nil {
+			if last != nil && ctx.Err() != nil {
+				return last, ctx.Err()
+			}
+			return nil, err
 		}
 
-	} else if src == "SAP_APPLICATION" {
-		return nil, nil
+		if gateway.Status() == "connected" {
+			return gateway, nil
+		}
+
+		last = gateway
+		tflog.Info(ctx, "Waiting for data gateway to connect", map[string]interface{}{
+			"id":    gatewayID,
+			"state": gateway.State,
+		})
+
+		select {
+		case <-ctx.Done():
+			return last, ctx.Err()
+		case <-time.After(gatewayPollInterval):
+		}
+	}
+}
+
+// GetConnectionString builds the connection string and credentials of a
+// connection of the data source type src from the properties of its
+// connector.
+func (r *DataConnectionResource) GetConnectionString(ctx context.Context, src string, props DataConnectionResourceModel) (*models.GetConnectionStringResponse, error) {
+	c, ok := connectors[src]
+	if !ok {
+		return nil, fmt.Errorf("data connections of type %q are not supported", src)
 	}
 
-	c, _ := r.client.GetConnectionString(src, conn, crd)
+	conn, crd := c.connectionStrings(src, props.GatewayID.ValueString(), props.ConnectionParameters)
 
-	return c, nil
+	return transport.WithContext(ctx, r.client).GetConnectionString(src, conn, crd)
 }
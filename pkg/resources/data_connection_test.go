@@ -0,0 +1,90 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestPollDataGateway(t *testing.T) {
+	defer func(interval time.Duration) { gatewayPollInterval = interval }(gatewayPollInterval)
+	gatewayPollInterval = time.Millisecond
+
+	states := []string{"DISCONNECTED", "DISCONNECTED", "CONNECTED"}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state := states[0]
+		if len(states) > 1 {
+			states = states[1:]
+		}
+
+		_, _ = w.Write([]byte(`{"id":"g1","name":"East","state":"` + state + `"}`))
+	}))
+	defer server.Close()
+
+	client := &qlikcloud.Client{HostURL: server.URL, HTTPClient: server.Client()}
+
+	gateway, err := pollDataGateway(context.Background(), client, "g1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if gateway.Status() != "connected" {
+		t.Errorf("got status %q, want connected", gateway.Status())
+	}
+}
+
+func TestPollDataGatewayTimeout(t *testing.T) {
+	defer func(interval time.Duration) { gatewayPollInterval = interval }(gatewayPollInterval)
+	gatewayPollInterval = time.Millisecond
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"g1","name":"East","state":"DISCONNECTED"}`))
+	}))
+	defer server.Close()
+
+	client := &qlikcloud.Client{HostURL: server.URL, HTTPClient: server.Client()}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	gateway, err := pollDataGateway(ctx, client, "g1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want a deadline exceeded error", err)
+	}
+
+	if gateway == nil || gateway.Status() != "disconnected" {
+		t.Errorf("got gateway %+v, want the last disconnected state", gateway)
+	}
+}
+
+func TestDataConnectionImportStateInvalidIdentifier(t *testing.T) {
+	ctx := context.Background()
+	r := &DataConnectionResource{}
+
+	var schema resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schema)
+
+	for _, id := range []string{"/Snowflake", "s1/", "/"} {
+		resp := &resource.ImportStateResponse{
+			State: tfsdk.State{
+				Schema: schema.Schema,
+				Raw:    tftypes.NewValue(schema.Schema.Type().TerraformType(ctx), nil),
+			},
+		}
+
+		r.ImportState(ctx, resource.ImportStateRequest{ID: id}, resp)
+
+		if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Unexpected Import Identifier" {
+			t.Errorf("import of %q got diagnostics %v, want an unexpected import identifier error", id, resp.Diagnostics)
+		}
+	}
+}
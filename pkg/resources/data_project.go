@@ -6,19 +6,24 @@ import (
 
 	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
 	"github.com/daniepett/qlik-cloud-client-go/models"
+	"github.com/daniepett/terraform-provider-qlik/pkg/qlik"
+	"github.com/daniepett/terraform-provider-qlik/pkg/transport"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &DataProjectResource{}
-	_ resource.ResourceWithConfigure = &DataProjectResource{}
-	// _ resource.ResourceWithImportState = &spaceResource{}
+	_ resource.Resource                = &DataProjectResource{}
+	_ resource.ResourceWithConfigure   = &DataProjectResource{}
+	_ resource.ResourceWithImportState = &DataProjectResource{}
 )
 
 // NewOrderResource is a helper function to simplify the provider implementation.
@@ -33,14 +38,15 @@ type DataProjectResource struct {
 
 // orderResourceModel maps the resource schema data.
 type DataProjectResourceModel struct {
-	ID                types.String `tfsdk:"id"`
-	Name              types.String `tfsdk:"name"`
-	Description       types.String `tfsdk:"description"`
-	SpaceID           types.String `tfsdk:"space_id"`
-	LakehouseType     types.String `tfsdk:"lakehouse_type"`
-	Type              types.String `tfsdk:"type"`
-	StorageConnection types.String `tfsdk:"storage_connection"`
-	BatchMode         types.Bool   `tfsdk:"batch_mode"`
+	ID                types.String   `tfsdk:"id"`
+	Name              types.String   `tfsdk:"name"`
+	Description       types.String   `tfsdk:"description"`
+	SpaceID           types.String   `tfsdk:"space_id"`
+	LakehouseType     types.String   `tfsdk:"lakehouse_type"`
+	Type              types.String   `tfsdk:"type"`
+	StorageConnection types.String   `tfsdk:"storage_connection"`
+	BatchMode         types.Bool     `tfsdk:"batch_mode"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata returns the resource type name.
@@ -49,8 +55,11 @@ func (r *DataProjectResource) Metadata(_ context.Context, req resource.MetadataR
 }
 
 // Schema defines the schema for the resource.
-func (r *DataProjectResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *DataProjectResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		// A data project cannot be moved to another space, and its platform,
+		// type and storage are fixed once it is created, so changing any of
+		// them replaces it.
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -66,21 +75,41 @@ func (r *DataProjectResource) Schema(_ context.Context, _ resource.SchemaRequest
 			},
 			"space_id": schema.StringAttribute{
 				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"lakehouse_type": schema.StringAttribute{
 				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"type": schema.StringAttribute{
 				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"storage_connection": schema.StringAttribute{
 				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"batch_mode": schema.BoolAttribute{
 				Computed: true,
 				Default:  booldefault.StaticBool(true),
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -114,6 +143,15 @@ func (r *DataProjectResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	newDataProject := models.DataProjectCreate{
 		SpaceID: plan.SpaceID.ValueString(),
 		Data: models.DataProjectConfiguration{
@@ -127,8 +165,11 @@ func (r *DataProjectResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	// Create new space
-	dataProject, err := r.client.CreateDataProject(newDataProject)
+	dataProject, err := transport.WithContext(ctx, r.client).CreateDataProject(newDataProject)
 	if err != nil {
+		if deadlineExceeded(ctx, &resp.Diagnostics, "create", createTimeout, err) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error creating data connection",
 			"Could not create order, unexpected error: "+err.Error(),
@@ -157,8 +198,25 @@ func (r *DataProjectResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	project, err := r.client.GetDataProject(state.ID.ValueString())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	project, err := transport.WithContext(ctx, r.client).GetDataProject(state.ID.ValueString())
 	if err != nil {
+		if qlik.IsNotFound(err) {
+			tflog.Warn(ctx, "Data project not found, removing it from state", map[string]interface{}{"id": state.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		if deadlineExceeded(ctx, &resp.Diagnostics, "read", readTimeout, err) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Reading Data Project",
 			"Could not read Data Project ID "+state.ID.ValueString()+": "+err.Error(),
@@ -167,7 +225,12 @@ func (r *DataProjectResource) Read(ctx context.Context, req resource.ReadRequest
 	}
 
 	state.Name = types.StringValue(project.DataProject.Name)
-	state.Description = types.StringValue(project.DataProject.Description)
+	state.Description = optionalString(project.DataProject.Description)
+	state.SpaceID = types.StringValue(project.Info.SpaceID)
+	state.LakehouseType = types.StringValue(project.DataProject.LakehouseType)
+	state.Type = types.StringValue(project.DataProject.Type)
+	state.StorageConnection = types.StringValue(project.DataProject.StorageConnection)
+	state.BatchMode = types.BoolValue(project.DataProject.BatchMode)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -186,6 +249,15 @@ func (r *DataProjectResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	project := models.DataProjectUpdate{
 		SpaceID: plan.SpaceID.ValueString(),
 		Data: models.DataProjectConfiguration{
@@ -200,8 +272,11 @@ func (r *DataProjectResource) Update(ctx context.Context, req resource.UpdateReq
 	}
 
 	// Create new space
-	_, err := r.client.UpdateDataProject(plan.ID.ValueString(), project)
+	_, err := transport.WithContext(ctx, r.client).UpdateDataProject(plan.ID.ValueString(), project)
 	if err != nil {
+		if deadlineExceeded(ctx, &resp.Diagnostics, "update", updateTimeout, err) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error updating data project",
 			"Could not update data project, unexpected error: "+err.Error(),
@@ -225,9 +300,25 @@ func (r *DataProjectResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Delete existing order
-	err := r.client.DeleteDataProject(state.ID.ValueString())
+	err := transport.WithContext(ctx, r.client).DeleteDataProject(state.ID.ValueString())
 	if err != nil {
+		// Objects deleted outside of Terraform are already gone.
+		if qlik.IsNotFound(err) {
+			return
+		}
+		if deadlineExceeded(ctx, &resp.Diagnostics, "delete", deleteTimeout, err) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Deleting Data Project",
 			"Could not delete Data Project, unexpected error: "+err.Error(),
@@ -236,7 +327,7 @@ func (r *DataProjectResource) Delete(ctx context.Context, req resource.DeleteReq
 	}
 }
 
-// func (r *orderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-// 	// Retrieve import ID and save to id attribute
-// 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
-// }
+// ImportState imports a data project by its ID.
+func (r *DataProjectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
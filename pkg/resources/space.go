@@ -3,23 +3,39 @@ package resources
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
 	"github.com/daniepett/qlik-cloud-client-go/models"
+	"github.com/daniepett/terraform-provider-qlik/pkg/qlik"
+	"github.com/daniepett/terraform-provider-qlik/pkg/transport"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &SpaceResource{}
-	_ resource.ResourceWithConfigure = &SpaceResource{}
-	// _ resource.ResourceWithImportState = &SpaceResource{}
+	_ resource.Resource                = &SpaceResource{}
+	_ resource.ResourceWithConfigure   = &SpaceResource{}
+	_ resource.ResourceWithImportState = &SpaceResource{}
+	_ resource.ResourceWithModifyPlan  = &SpaceResource{}
 )
 
+// spaceTypes are the types a space can be created with.
+var spaceTypes = []string{"shared", "managed", "data"}
+
+// spaceImportNamePrefix marks an import ID as a space name rather than an ID.
+const spaceImportNamePrefix = "name:"
+
 // NewOrderResource is a helper function to simplify the provider implementation.
 func NewSpaceResource() resource.Resource {
 	return &SpaceResource{}
@@ -32,11 +48,14 @@ type SpaceResource struct {
 
 // orderResourceModel maps the resource schema data.
 type SpaceResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Type        types.String `tfsdk:"type"`
-	Description types.String `tfsdk:"description"`
-	OwnerID     types.String `tfsdk:"owner_id"`
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	Type        types.String   `tfsdk:"type"`
+	Description types.String   `tfsdk:"description"`
+	OwnerID     types.String   `tfsdk:"owner_id"`
+	OwnerEmail  types.String   `tfsdk:"owner_email"`
+	OwnerName   types.String   `tfsdk:"owner_name"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata returns the resource type name.
@@ -45,7 +64,7 @@ func (r *SpaceResource) Metadata(_ context.Context, req resource.MetadataRequest
 }
 
 // Schema defines the schema for the resource.
-func (r *SpaceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *SpaceResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -59,17 +78,67 @@ func (r *SpaceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			},
 			"type": schema.StringAttribute{
 				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(spaceTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						requiresReplaceSpaceType,
+						"Changing the type of a space replaces it, deleting its content.",
+						"Changing the type of a space replaces it, deleting its content.",
+					),
+				},
 			},
 			"description": schema.StringAttribute{
 				Optional: true,
 			},
 			"owner_id": schema.StringAttribute{
+				Optional: true,
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(
+						path.MatchRoot("owner_email"),
+						path.MatchRoot("owner_name"),
+					),
+				},
+			},
+			"owner_email": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("owner_name")),
+				},
+			},
+			"owner_name": schema.StringAttribute{
+				Optional: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
+// requiresReplaceSpaceType replaces a space whose type changes, which the API
+// cannot update in place, warning that the content of the space goes with it.
+func requiresReplaceSpaceType(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = true
+	resp.Diagnostics.AddAttributeWarning(
+		req.Path,
+		"Space Will Be Replaced",
+		fmt.Sprintf("The type of a space cannot be changed in place, so changing it from %q to %q deletes the space and creates a new one. "+
+			"Apps, data connections and other content in the space are deleted along with it.",
+			req.StateValue.ValueString(), req.PlanValue.ValueString()),
+	)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *SpaceResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -100,6 +169,23 @@ func (r *SpaceResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// The owner is looked up before the space is created, so a user that
+	// cannot be found does not leave a space behind.
+	ownerID, diags := spaceOwner(ctx, r.client, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	newSpace := models.CreateSpace{
 		Name:        plan.Name.ValueString(),
 		Type:        plan.Type.ValueString(),
@@ -107,8 +193,11 @@ func (r *SpaceResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	// Create new Space
-	Space, err := r.client.CreateSpace(newSpace)
+	Space, err := transport.WithContext(ctx, r.client).CreateSpace(newSpace)
 	if err != nil {
+		if deadlineExceeded(ctx, &resp.Diagnostics, "create", createTimeout, err) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error creating order",
 			"Could not create order, unexpected error: "+err.Error(),
@@ -116,6 +205,26 @@ func (r *SpaceResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	// Spaces are created with the caller as owner, so they are handed to the
+	// configured owner afterwards. The space is kept in state if that fails.
+	if ownerID.ValueString() != "" && ownerID.ValueString() != Space.OwnerID {
+		transferred, err := transport.WithContext(ctx, r.client).UpdateSpace(Space.ID, models.UpdateSpace{
+			Name:        Space.Name,
+			OwnerID:     ownerID.ValueString(),
+			Description: Space.Description,
+		})
+		if err != nil {
+			if !deadlineExceeded(ctx, &resp.Diagnostics, "create", createTimeout, err) {
+				resp.Diagnostics.AddError(
+					"Error Transferring Space",
+					"Created space "+Space.ID+" but could not make "+ownerID.ValueString()+" its owner: "+err.Error(),
+				)
+			}
+		} else {
+			Space = transferred
+		}
+	}
+
 	// Map response body to schema and populate Computed attribute values
 	plan.ID = types.StringValue(Space.ID)
 	plan.OwnerID = types.StringValue(Space.OwnerID)
@@ -138,8 +247,25 @@ func (r *SpaceResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	Space, err := r.client.GetSpace(state.ID.ValueString())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	Space, err := transport.WithContext(ctx, r.client).GetSpace(state.ID.ValueString())
 	if err != nil {
+		if qlik.IsNotFound(err) {
+			tflog.Warn(ctx, "Space not found, removing it from state", map[string]interface{}{"id": state.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		if deadlineExceeded(ctx, &resp.Diagnostics, "read", readTimeout, err) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Reading Space",
 			"Could not read Space ID "+state.ID.ValueString()+": "+err.Error(),
@@ -150,7 +276,7 @@ func (r *SpaceResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	state.ID = types.StringValue(Space.ID)
 	state.Name = types.StringValue(Space.Name)
 	state.Type = types.StringValue(Space.Type)
-	state.Description = types.StringValue(Space.Description)
+	state.Description = optionalString(Space.Description)
 	state.OwnerID = types.StringValue(Space.OwnerID)
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -169,15 +295,33 @@ func (r *SpaceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	ownerID, diags := spaceOwner(ctx, r.client, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	updateSpace := models.UpdateSpace{
 		Name:        plan.Name.ValueString(),
-		OwnerID:     plan.OwnerID.ValueString(),
+		OwnerID:     ownerID.ValueString(),
 		Description: plan.Description.ValueString(),
 	}
 
 	// Create new Space
-	Space, err := r.client.UpdateSpace(plan.ID.ValueString(), updateSpace)
+	Space, err := transport.WithContext(ctx, r.client).UpdateSpace(plan.ID.ValueString(), updateSpace)
 	if err != nil {
+		if deadlineExceeded(ctx, &resp.Diagnostics, "update", updateTimeout, err) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error updating Space",
 			"Could not update Space, unexpected error: "+err.Error(),
@@ -203,9 +347,25 @@ func (r *SpaceResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Delete existing order
-	err := r.client.DeleteSpace(state.ID.ValueString())
+	err := transport.WithContext(ctx, r.client).DeleteSpace(state.ID.ValueString())
 	if err != nil {
+		// Objects deleted outside of Terraform are already gone.
+		if qlik.IsNotFound(err) {
+			return
+		}
+		if deadlineExceeded(ctx, &resp.Diagnostics, "delete", deleteTimeout, err) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error Deleting Space",
 			"Could not delete Space, unexpected error: "+err.Error(),
@@ -214,7 +374,128 @@ func (r *SpaceResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 }
 
-// func (r *orderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-// 	// Retrieve import ID and save to id attribute
-// 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
-// }
+// ModifyPlan looks up the user given by owner_email or owner_name and plans
+// their ID as owner_id, so a space handed to someone else outside of Terraform
+// shows up as a change.
+func (r *SpaceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the space is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan SpaceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.OwnerEmail.IsNull() && plan.OwnerName.IsNull() {
+		return
+	}
+
+	// Owners that are not known yet are looked up when the plan is applied.
+	if plan.OwnerEmail.IsUnknown() || plan.OwnerName.IsUnknown() || r.client == nil {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("owner_id"), types.StringUnknown())...)
+		return
+	}
+
+	ownerID, diags := spaceOwner(ctx, r.client, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("owner_id"), ownerID)...)
+}
+
+// spaceOwner returns the ID of the user that should own the space, looking
+// the user up when the owner is given by email or name. Without either it
+// returns the planned owner_id, which is unknown when the owner is left to the
+// API.
+func spaceOwner(ctx context.Context, client *qlikcloud.Client, model SpaceResourceModel) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var (
+		filter    qlik.UserFilter
+		attribute path.Path
+		value     string
+	)
+
+	switch {
+	case !model.OwnerEmail.IsNull():
+		filter.Email = model.OwnerEmail.ValueString()
+		attribute, value = path.Root("owner_email"), filter.Email
+	case !model.OwnerName.IsNull():
+		filter.Name = model.OwnerName.ValueString()
+		attribute, value = path.Root("owner_name"), filter.Name
+	default:
+		return model.OwnerID, diags
+	}
+
+	users, err := qlik.ListUsers(transport.WithContext(ctx, client), filter)
+	if err != nil {
+		diags.AddAttributeError(
+			attribute,
+			"Error Looking Up Space Owner",
+			fmt.Sprintf("Could not look up the user %q: %s", value, err),
+		)
+		return types.StringUnknown(), diags
+	}
+
+	if len(users) != 1 {
+		diags.AddAttributeError(
+			attribute,
+			"Error Looking Up Space Owner",
+			fmt.Sprintf("Found %d users matching %q, expected exactly one.", len(users), value),
+		)
+		return types.StringUnknown(), diags
+	}
+
+	return types.StringValue(users[0].ID), diags
+}
+
+// ImportState imports a space by its ID, or by its name when the import ID
+// is prefixed with "name:".
+func (r *SpaceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !strings.HasPrefix(req.ID, spaceImportNamePrefix) {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	name := strings.TrimPrefix(req.ID, spaceImportNamePrefix)
+
+	spaces, err := qlik.ListSpaces(transport.WithContext(ctx, r.client), qlik.SpaceFilter{Name: name}, 0)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Space",
+			"Could not list spaces named "+name+": "+err.Error(),
+		)
+		return
+	}
+
+	// The name filter of the spaces API also matches partial names.
+	var ids []string
+	for _, space := range spaces {
+		if space.Name == name {
+			ids = append(ids, space.ID)
+		}
+	}
+
+	if len(ids) == 0 {
+		resp.Diagnostics.AddError(
+			"Error Importing Space",
+			fmt.Sprintf("No space named %q was found.", name),
+		)
+		return
+	}
+
+	if len(ids) > 1 {
+		resp.Diagnostics.AddError(
+			"Error Importing Space",
+			fmt.Sprintf("Found %d spaces named %q (%s), import the space by its ID instead.", len(ids), name, strings.Join(ids, ", ")),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), ids[0])...)
+}
@@ -0,0 +1,346 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+	"github.com/daniepett/terraform-provider-qlik/pkg/qlik"
+	"github.com/daniepett/terraform-provider-qlik/pkg/transport"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &SpaceAssignmentResource{}
+	_ resource.ResourceWithConfigure   = &SpaceAssignmentResource{}
+	_ resource.ResourceWithImportState = &SpaceAssignmentResource{}
+)
+
+// spaceAssigneeTypes are the kinds of assignees a space can be shared with.
+var spaceAssigneeTypes = []string{"user", "group"}
+
+// spaceRoles are the roles an assignee can have in a space. Which of them
+// apply depends on the type of the space.
+var spaceRoles = []string{
+	"basicconsumer",
+	"codeveloper",
+	"consumer",
+	"contributor",
+	"dataconsumer",
+	"facilitator",
+	"operator",
+	"producer",
+	"publisher",
+}
+
+// NewSpaceAssignmentResource is a helper function to simplify the provider implementation.
+func NewSpaceAssignmentResource() resource.Resource {
+	return &SpaceAssignmentResource{}
+}
+
+// SpaceAssignmentResource is the resource implementation.
+type SpaceAssignmentResource struct {
+	client *qlikcloud.Client
+}
+
+// SpaceAssignmentResourceModel maps the resource schema data.
+type SpaceAssignmentResourceModel struct {
+	ID         types.String   `tfsdk:"id"`
+	SpaceID    types.String   `tfsdk:"space_id"`
+	Type       types.String   `tfsdk:"type"`
+	AssigneeID types.String   `tfsdk:"assignee_id"`
+	Roles      []types.String `tfsdk:"roles"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *SpaceAssignmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space_assignment"
+}
+
+// Schema defines the schema for the resource.
+func (r *SpaceAssignmentResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// Only the roles of an assignment can be updated, so a different
+		// space or assignee replaces it.
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"space_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(spaceAssigneeTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"assignee_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"roles": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(spaceRoles...)),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *SpaceAssignmentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*qlikcloud.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *qlikcloud.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Create a new resource.
+func (r *SpaceAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan SpaceAssignmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	assignment, err := qlik.CreateSpaceAssignment(transport.WithContext(ctx, r.client), plan.SpaceID.ValueString(), qlik.SpaceAssignment{
+		Type:       plan.Type.ValueString(),
+		AssigneeID: plan.AssigneeID.ValueString(),
+		Roles:      roleNames(plan.Roles),
+	})
+	if err != nil {
+		if deadlineExceeded(ctx, &resp.Diagnostics, "create", createTimeout, err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Creating Space Assignment",
+			"Could not assign "+plan.AssigneeID.ValueString()+" to space "+plan.SpaceID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	plan.ID = types.StringValue(assignment.ID)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *SpaceAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state SpaceAssignmentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	assignment, err := qlik.GetSpaceAssignment(transport.WithContext(ctx, r.client), state.SpaceID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		if qlik.IsNotFound(err) {
+			tflog.Warn(ctx, "Space assignment not found, removing it from state", map[string]interface{}{"id": state.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		if deadlineExceeded(ctx, &resp.Diagnostics, "read", readTimeout, err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Space Assignment",
+			"Could not read Space Assignment ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.Type = types.StringValue(assignment.Type)
+	state.AssigneeID = types.StringValue(assignment.AssigneeID)
+	state.Roles = roleValues(assignment.Roles)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *SpaceAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan SpaceAssignmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	_, err := qlik.UpdateSpaceAssignment(transport.WithContext(ctx, r.client), plan.SpaceID.ValueString(), plan.ID.ValueString(), roleNames(plan.Roles))
+	if err != nil {
+		if deadlineExceeded(ctx, &resp.Diagnostics, "update", updateTimeout, err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Updating Space Assignment",
+			"Could not update Space Assignment ID "+plan.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *SpaceAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state SpaceAssignmentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := qlik.DeleteSpaceAssignment(transport.WithContext(ctx, r.client), state.SpaceID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		// Objects deleted outside of Terraform are already gone.
+		if qlik.IsNotFound(err) {
+			return
+		}
+		if deadlineExceeded(ctx, &resp.Diagnostics, "delete", deleteTimeout, err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Deleting Space Assignment",
+			"Could not delete Space Assignment, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports a space assignment by the ID of its space and its own
+// ID separated by a slash, as both are needed to read it.
+func (r *SpaceAssignmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	spaceID, assignmentID, found := strings.Cut(req.ID, "/")
+	if !found || spaceID == "" || assignmentID == "" || strings.Contains(assignmentID, "/") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected an import identifier of the form <space_id>/<assignment_id>, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("space_id"), spaceID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), assignmentID)...)
+}
+
+// roleNames returns the roles of an assignment as sent to the API.
+func roleNames(roles []types.String) []string {
+	names := make([]string, 0, len(roles))
+	for _, role := range roles {
+		names = append(names, role.ValueString())
+	}
+
+	return names
+}
+
+// roleValues returns the roles of an assignment as stored in state.
+func roleValues(roles []string) []types.String {
+	values := make([]types.String, 0, len(roles))
+	for _, role := range roles {
+		values = append(values, types.StringValue(role))
+	}
+
+	return values
+}
@@ -0,0 +1,432 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+	"github.com/daniepett/terraform-provider-qlik/pkg/qlik"
+	"github.com/daniepett/terraform-provider-qlik/pkg/transport"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &SpaceAssignmentsResource{}
+	_ resource.ResourceWithConfigure   = &SpaceAssignmentsResource{}
+	_ resource.ResourceWithImportState = &SpaceAssignmentsResource{}
+)
+
+// NewSpaceAssignmentsResource is a helper function to simplify the provider implementation.
+func NewSpaceAssignmentsResource() resource.Resource {
+	return &SpaceAssignmentsResource{}
+}
+
+// SpaceAssignmentsResource manages every assignment of a space, removing the
+// ones that are not in the configuration.
+type SpaceAssignmentsResource struct {
+	client *qlikcloud.Client
+}
+
+// SpaceAssignmentsResourceModel maps the resource schema data.
+type SpaceAssignmentsResourceModel struct {
+	ID          types.String           `tfsdk:"id"`
+	SpaceID     types.String           `tfsdk:"space_id"`
+	Assignments []SpaceAssignmentModel `tfsdk:"assignments"`
+	Timeouts    timeouts.Value         `tfsdk:"timeouts"`
+}
+
+// SpaceAssignmentModel maps a single assignment of a space.
+type SpaceAssignmentModel struct {
+	Type       types.String   `tfsdk:"type"`
+	AssigneeID types.String   `tfsdk:"assignee_id"`
+	Roles      []types.String `tfsdk:"roles"`
+}
+
+// Metadata returns the resource type name.
+func (r *SpaceAssignmentsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space_assignments"
+}
+
+// Schema defines the schema for the resource.
+func (r *SpaceAssignmentsResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"space_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"assignments": schema.SetNestedAttribute{
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Required: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(spaceAssigneeTypes...),
+							},
+						},
+						"assignee_id": schema.StringAttribute{
+							Required: true,
+						},
+						"roles": schema.SetAttribute{
+							Required:    true,
+							ElementType: types.StringType,
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+								setvalidator.ValueStringsAre(stringvalidator.OneOf(spaceRoles...)),
+							},
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *SpaceAssignmentsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*qlikcloud.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *qlikcloud.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Create a new resource.
+func (r *SpaceAssignmentsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan SpaceAssignmentsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	err := r.reconcile(ctx, plan)
+	if err != nil {
+		if deadlineExceeded(ctx, &resp.Diagnostics, "create", createTimeout, err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Creating Space Assignments",
+			"Could not set the assignments of space "+plan.SpaceID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	plan.ID = plan.SpaceID
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *SpaceAssignmentsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state SpaceAssignmentsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	assignments, err := qlik.ListSpaceAssignments(transport.WithContext(ctx, r.client), state.SpaceID.ValueString())
+	if err != nil {
+		if qlik.IsNotFound(err) {
+			tflog.Warn(ctx, "Space not found, removing its assignments from state", map[string]interface{}{"space_id": state.SpaceID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		if deadlineExceeded(ctx, &resp.Diagnostics, "read", readTimeout, err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Space Assignments",
+			"Could not read the assignments of space "+state.SpaceID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Every assignment is kept in state, so members added outside of
+	// Terraform show up as drift and are removed on the next apply.
+	state.ID = state.SpaceID
+	state.Assignments = []SpaceAssignmentModel{}
+	for _, assignment := range assignments {
+		state.Assignments = append(state.Assignments, SpaceAssignmentModel{
+			Type:       types.StringValue(assignment.Type),
+			AssigneeID: types.StringValue(assignment.AssigneeID),
+			Roles:      roleValues(assignment.Roles),
+		})
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *SpaceAssignmentsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan SpaceAssignmentsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	err := r.reconcile(ctx, plan)
+	if err != nil {
+		if deadlineExceeded(ctx, &resp.Diagnostics, "update", updateTimeout, err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Updating Space Assignments",
+			"Could not set the assignments of space "+plan.SpaceID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *SpaceAssignmentsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state SpaceAssignmentsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// The resource manages every assignment of the space, so destroying it
+	// removes them all.
+	state.Assignments = nil
+
+	err := r.reconcile(ctx, state)
+	if err != nil {
+		// Assignments of a space deleted outside of Terraform are already gone.
+		if qlik.IsNotFound(err) {
+			return
+		}
+		if deadlineExceeded(ctx, &resp.Diagnostics, "delete", deleteTimeout, err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Deleting Space Assignments",
+			"Could not remove the assignments of space "+state.SpaceID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports the assignments of a space by the ID of the space.
+func (r *SpaceAssignmentsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("space_id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// reconcile makes the assignments of the space match model, creating and
+// updating assignments before removing the ones that are no longer wanted.
+func (r *SpaceAssignmentsResource) reconcile(ctx context.Context, model SpaceAssignmentsResourceModel) error {
+	client := transport.WithContext(ctx, r.client)
+	spaceID := model.SpaceID.ValueString()
+
+	current, err := qlik.ListSpaceAssignments(client, spaceID)
+	if err != nil {
+		return err
+	}
+
+	var desired []qlik.SpaceAssignment
+	for _, assignment := range model.Assignments {
+		desired = append(desired, qlik.SpaceAssignment{
+			Type:       assignment.Type.ValueString(),
+			AssigneeID: assignment.AssigneeID.ValueString(),
+			Roles:      roleNames(assignment.Roles),
+		})
+	}
+
+	changes, err := diffSpaceAssignments(current, desired)
+	if err != nil {
+		return err
+	}
+
+	for _, assignment := range changes.Create {
+		_, err := qlik.CreateSpaceAssignment(client, spaceID, assignment)
+		if err != nil {
+			return fmt.Errorf("assigning %s %s: %w", assignment.Type, assignment.AssigneeID, err)
+		}
+	}
+
+	for _, assignment := range changes.Update {
+		_, err := qlik.UpdateSpaceAssignment(client, spaceID, assignment.ID, assignment.Roles)
+		if err != nil {
+			return fmt.Errorf("updating the roles of %s %s: %w", assignment.Type, assignment.AssigneeID, err)
+		}
+	}
+
+	for _, assignment := range changes.Delete {
+		err := qlik.DeleteSpaceAssignment(client, spaceID, assignment.ID)
+		if err != nil && !qlik.IsNotFound(err) {
+			return fmt.Errorf("removing %s %s: %w", assignment.Type, assignment.AssigneeID, err)
+		}
+	}
+
+	return nil
+}
+
+// spaceAssignmentChanges are the calls that turn the current assignments of a
+// space into the desired ones.
+type spaceAssignmentChanges struct {
+	Create []qlik.SpaceAssignment
+	Update []qlik.SpaceAssignment
+	Delete []qlik.SpaceAssignment
+}
+
+// diffSpaceAssignments compares the current and desired assignments of a
+// space by assignee. Assignees whose roles differ are updated in place.
+func diffSpaceAssignments(current, desired []qlik.SpaceAssignment) (spaceAssignmentChanges, error) {
+	key := func(a qlik.SpaceAssignment) string {
+		return a.Type + "/" + a.AssigneeID
+	}
+
+	existing := map[string]qlik.SpaceAssignment{}
+	for _, assignment := range current {
+		existing[key(assignment)] = assignment
+	}
+
+	var changes spaceAssignmentChanges
+
+	wanted := map[string]bool{}
+	for _, assignment := range desired {
+		k := key(assignment)
+		if wanted[k] {
+			return spaceAssignmentChanges{}, fmt.Errorf("%s %s is assigned more than once, combine its roles into a single assignment", assignment.Type, assignment.AssigneeID)
+		}
+		wanted[k] = true
+
+		found, ok := existing[k]
+		if !ok {
+			changes.Create = append(changes.Create, assignment)
+			continue
+		}
+
+		if !sameRoles(found.Roles, assignment.Roles) {
+			found.Roles = assignment.Roles
+			changes.Update = append(changes.Update, found)
+		}
+	}
+
+	for _, assignment := range current {
+		if !wanted[key(assignment)] {
+			changes.Delete = append(changes.Delete, assignment)
+		}
+	}
+
+	return changes, nil
+}
+
+// sameRoles reports whether a and b hold the same roles in any order.
+func sameRoles(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	a = append([]string(nil), a...)
+	b = append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
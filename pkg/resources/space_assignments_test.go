@@ -0,0 +1,54 @@
+package resources
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/daniepett/terraform-provider-qlik/pkg/qlik"
+)
+
+func TestDiffSpaceAssignments(t *testing.T) {
+	current := []qlik.SpaceAssignment{
+		{ID: "a1", Type: "user", AssigneeID: "alice", Roles: []string{"consumer", "contributor"}},
+		{ID: "a2", Type: "user", AssigneeID: "bob", Roles: []string{"consumer"}},
+		{ID: "a3", Type: "group", AssigneeID: "analysts", Roles: []string{"consumer"}},
+	}
+
+	desired := []qlik.SpaceAssignment{
+		{Type: "user", AssigneeID: "alice", Roles: []string{"contributor", "consumer"}},
+		{Type: "group", AssigneeID: "analysts", Roles: []string{"consumer", "dataconsumer"}},
+		{Type: "group", AssigneeID: "bob", Roles: []string{"facilitator"}},
+	}
+
+	got, err := diffSpaceAssignments(current, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := spaceAssignmentChanges{
+		Create: []qlik.SpaceAssignment{
+			{Type: "group", AssigneeID: "bob", Roles: []string{"facilitator"}},
+		},
+		Update: []qlik.SpaceAssignment{
+			{ID: "a3", Type: "group", AssigneeID: "analysts", Roles: []string{"consumer", "dataconsumer"}},
+		},
+		Delete: []qlik.SpaceAssignment{
+			{ID: "a2", Type: "user", AssigneeID: "bob", Roles: []string{"consumer"}},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestDiffSpaceAssignmentsDuplicate(t *testing.T) {
+	desired := []qlik.SpaceAssignment{
+		{Type: "user", AssigneeID: "alice", Roles: []string{"consumer"}},
+		{Type: "user", AssigneeID: "alice", Roles: []string{"contributor"}},
+	}
+
+	if _, err := diffSpaceAssignments(nil, desired); err == nil {
+		t.Error("expected an error for an assignee listed twice")
+	}
+}
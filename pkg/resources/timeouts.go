@@ -0,0 +1,37 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// Default timeouts used when a resource does not configure its own in the
+// timeouts block.
+const (
+	defaultCreateTimeout = 20 * time.Minute
+	defaultReadTimeout   = 5 * time.Minute
+	defaultUpdateTimeout = 20 * time.Minute
+	defaultDeleteTimeout = 20 * time.Minute
+)
+
+// deadlineExceeded adds an error diagnostic and returns true when an operation
+// failed because its timeout expired, so the caller can report it instead of
+// the underlying request error.
+func deadlineExceeded(ctx context.Context, diags *diag.Diagnostics, operation string, timeout time.Duration, err error) bool {
+	if !errors.Is(err, context.DeadlineExceeded) && !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return false
+	}
+
+	diags.AddError(
+		"Timeout Exceeded",
+		fmt.Sprintf("The %s operation did not complete within its timeout of %s. "+
+			"If Qlik Cloud needs more time, increase the %s value in the timeouts block of the resource.\n\n"+
+			"Error: %s", operation, timeout, operation, err),
+	)
+
+	return true
+}
@@ -0,0 +1,559 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+	"github.com/daniepett/terraform-provider-qlik/pkg/qlik"
+	"github.com/daniepett/terraform-provider-qlik/pkg/transport"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &UserResource{}
+	_ resource.ResourceWithConfigure        = &UserResource{}
+	_ resource.ResourceWithConfigValidators = &UserResource{}
+	_ resource.ResourceWithImportState      = &UserResource{}
+)
+
+// userStatuses are the statuses a user can be given.
+var userStatuses = []string{"active", "disabled"}
+
+// NewUserResource is a helper function to simplify the provider implementation.
+func NewUserResource() resource.Resource {
+	return &UserResource{}
+}
+
+// UserResource is the resource implementation.
+type UserResource struct {
+	client *qlikcloud.Client
+}
+
+// UserResourceModel maps the resource schema data.
+type UserResourceModel struct {
+	ID               types.String   `tfsdk:"id"`
+	Email            types.String   `tfsdk:"email"`
+	Subject          types.String   `tfsdk:"subject"`
+	Name             types.String   `tfsdk:"name"`
+	AssignedRoles    types.Set      `tfsdk:"assigned_roles"`
+	Status           types.String   `tfsdk:"status"`
+	DisableOnDestroy types.Bool     `tfsdk:"disable_on_destroy"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *UserResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+// Schema defines the schema for the resource.
+func (r *UserResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// Users with a subject are provisioned directly, users with only an
+		// email are invited. Neither can be changed afterwards, so a
+		// different email or subject replaces the user.
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"email": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"subject": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"assigned_roles": schema.SetAttribute{
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(userStatuses...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"disable_on_destroy": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ConfigValidators requires a user to be created with an email to invite or
+// a subject to provision.
+func (r *UserResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("email"),
+			path.MatchRoot("subject"),
+		),
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *UserResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*qlikcloud.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *qlikcloud.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Create a new resource.
+func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan UserResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	var roles []string
+	if !plan.AssignedRoles.IsUnknown() {
+		diags = plan.AssignedRoles.ElementsAs(ctx, &roles, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	client := transport.WithContext(ctx, r.client)
+
+	var id string
+	invited := plan.Subject.ValueString() == ""
+	if !invited {
+		user, err := qlik.CreateUser(client, qlik.User{
+			Subject:       plan.Subject.ValueString(),
+			Name:          plan.Name.ValueString(),
+			Email:         plan.Email.ValueString(),
+			Status:        plan.Status.ValueString(),
+			AssignedRoles: assignedRoles(roles),
+		})
+		if err != nil {
+			if deadlineExceeded(ctx, &resp.Diagnostics, "create", createTimeout, err) {
+				return
+			}
+			resp.Diagnostics.AddError(
+				"Error Creating User",
+				"Could not create user "+plan.Subject.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+
+		id = user.ID
+	} else {
+		var err error
+		id, err = qlik.InviteUser(client, plan.Email.ValueString(), plan.Name.ValueString())
+		if err != nil {
+			if deadlineExceeded(ctx, &resp.Diagnostics, "create", createTimeout, err) {
+				return
+			}
+			resp.Diagnostics.AddError(
+				"Error Inviting User",
+				"Could not invite user "+plan.Email.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// Invites only take an email and a name, so the roles and status are set
+	// on the invited user. Users created without roles get the default roles
+	// of the tenant, which are removed when no roles are configured.
+	var operations []qlik.PatchOperation
+	if !plan.AssignedRoles.IsUnknown() && (invited || len(roles) == 0) {
+		operations = append(operations, qlik.PatchOperation{Op: "replace", Path: "/assignedRoles", Value: assignedRoles(roles)})
+	}
+	if invited && plan.Status.ValueString() == "disabled" {
+		operations = append(operations, qlik.PatchOperation{Op: "replace", Path: "/status", Value: "disabled"})
+	}
+
+	if len(operations) > 0 {
+		err := qlik.PatchUser(client, id, operations)
+		if err != nil {
+			// The user exists, so it is saved in state even though the
+			// failed create taints it. The next apply then replaces it
+			// through Delete, which honors disable_on_destroy, instead of
+			// leaving it behind.
+			plan.created(id)
+			resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+			if deadlineExceeded(ctx, &resp.Diagnostics, "create", createTimeout, err) {
+				return
+			}
+			resp.Diagnostics.AddError(
+				"Error Updating User",
+				"User "+id+" was created, but its roles or status could not be set: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	user, err := qlik.GetUser(client, id)
+	if err != nil {
+		plan.created(id)
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		if deadlineExceeded(ctx, &resp.Diagnostics, "create", createTimeout, err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading User",
+			"Could not read User ID "+id+": "+err.Error(),
+		)
+		return
+	}
+
+	// Map response body to schema and populate Computed attribute values
+	resp.Diagnostics.Append(plan.refresh(ctx, user)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state UserResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	user, err := qlik.GetUser(transport.WithContext(ctx, r.client), state.ID.ValueString())
+	if err != nil {
+		if qlik.IsNotFound(err) {
+			tflog.Warn(ctx, "User not found, removing it from state", map[string]interface{}{"id": state.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		if deadlineExceeded(ctx, &resp.Diagnostics, "read", readTimeout, err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading User",
+			"Could not read User ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(state.refresh(ctx, user)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan, state UserResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	var operations []qlik.PatchOperation
+	if !plan.Name.Equal(state.Name) {
+		operations = append(operations, qlik.PatchOperation{Op: "replace", Path: "/name", Value: plan.Name.ValueString()})
+	}
+	if !plan.AssignedRoles.Equal(state.AssignedRoles) {
+		var roles []string
+		diags = plan.AssignedRoles.ElementsAs(ctx, &roles, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		operations = append(operations, qlik.PatchOperation{Op: "replace", Path: "/assignedRoles", Value: assignedRoles(roles)})
+	}
+	if !plan.Status.Equal(state.Status) {
+		operations = append(operations, qlik.PatchOperation{Op: "replace", Path: "/status", Value: plan.Status.ValueString()})
+	}
+
+	client := transport.WithContext(ctx, r.client)
+
+	if len(operations) > 0 {
+		err := qlik.PatchUser(client, plan.ID.ValueString(), operations)
+		if err != nil {
+			if deadlineExceeded(ctx, &resp.Diagnostics, "update", updateTimeout, err) {
+				return
+			}
+			resp.Diagnostics.AddError(
+				"Error Updating User",
+				"Could not update User ID "+plan.ID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	user, err := qlik.GetUser(client, plan.ID.ValueString())
+	if err != nil {
+		if deadlineExceeded(ctx, &resp.Diagnostics, "update", updateTimeout, err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading User",
+			"Could not read User ID "+plan.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(plan.refresh(ctx, user)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state UserResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	client := transport.WithContext(ctx, r.client)
+
+	var err error
+	if state.DisableOnDestroy.ValueBool() {
+		// Disabled users keep their content and can be enabled again.
+		err = qlik.PatchUser(client, state.ID.ValueString(), []qlik.PatchOperation{
+			{Op: "replace", Path: "/status", Value: "disabled"},
+		})
+	} else {
+		err = qlik.DeleteUser(client, state.ID.ValueString())
+	}
+	if err != nil {
+		// Objects deleted outside of Terraform are already gone.
+		if qlik.IsNotFound(err) {
+			return
+		}
+		if deadlineExceeded(ctx, &resp.Diagnostics, "delete", deleteTimeout, err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Deleting User",
+			"Could not delete User, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports a user by its ID, or by its email when the import ID
+// contains an @.
+func (r *UserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Imported users are deleted on destroy, like those created by Terraform,
+	// unless disable_on_destroy is configured.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("disable_on_destroy"), false)...)
+
+	if !strings.Contains(req.ID, "@") {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	users, err := qlik.ListUsers(transport.WithContext(ctx, r.client), qlik.UserFilter{Email: req.ID})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing User",
+			"Could not list users with email "+req.ID+": "+err.Error(),
+		)
+		return
+	}
+
+	if len(users) == 0 {
+		resp.Diagnostics.AddError(
+			"Error Importing User",
+			fmt.Sprintf("No user with email %q was found.", req.ID),
+		)
+		return
+	}
+
+	if len(users) > 1 {
+		ids := make([]string, 0, len(users))
+		for _, user := range users {
+			ids = append(ids, user.ID)
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Importing User",
+			fmt.Sprintf("Found %d users with email %q (%s), import the user by its ID instead.", len(users), req.ID, strings.Join(ids, ", ")),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), users[0].ID)...)
+}
+
+// created sets the ID of a user that was created but could not be read back,
+// leaving the values it was created with and nulling those that are only
+// known once it is read.
+func (m *UserResourceModel) created(id string) {
+	m.ID = types.StringValue(id)
+
+	for _, value := range []*types.String{&m.Email, &m.Subject, &m.Name, &m.Status} {
+		if value.IsUnknown() {
+			*value = types.StringNull()
+		}
+	}
+
+	if m.AssignedRoles.IsUnknown() {
+		m.AssignedRoles = types.SetNull(types.StringType)
+	}
+}
+
+// refresh maps a user returned by the API to the model.
+func (m *UserResourceModel) refresh(ctx context.Context, user *qlik.User) diag.Diagnostics {
+	m.ID = types.StringValue(user.ID)
+	m.Email = types.StringValue(user.Email)
+	m.Subject = types.StringValue(user.Subject)
+	m.Name = types.StringValue(user.Name)
+
+	// Invited users become active when they first sign in, so they are not
+	// reported as drift from a configured status of active.
+	if user.Status != "invited" || m.Status.ValueString() != "active" {
+		m.Status = types.StringValue(user.Status)
+	}
+
+	roles := make([]string, 0, len(user.AssignedRoles))
+	for _, role := range user.AssignedRoles {
+		roles = append(roles, role.Name)
+	}
+
+	var diags diag.Diagnostics
+	m.AssignedRoles, diags = types.SetValueFrom(ctx, types.StringType, roles)
+
+	return diags
+}
+
+// assignedRoles returns roles by name as sent to the API.
+func assignedRoles(names []string) []qlik.AssignedRole {
+	roles := make([]qlik.AssignedRole, 0, len(names))
+	for _, name := range names {
+		roles = append(roles, qlik.AssignedRole{Name: name})
+	}
+
+	return roles
+}
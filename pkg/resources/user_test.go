@@ -0,0 +1,36 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestUserResourceModelCreated(t *testing.T) {
+	model := UserResourceModel{
+		Email:            types.StringValue("jane.doe@example.com"),
+		Subject:          types.StringUnknown(),
+		Name:             types.StringValue("Jane Doe"),
+		AssignedRoles:    types.SetUnknown(types.StringType),
+		Status:           types.StringUnknown(),
+		DisableOnDestroy: types.BoolValue(true),
+	}
+
+	model.created("u1")
+
+	if model.ID.ValueString() != "u1" {
+		t.Errorf("got ID %s, want u1", model.ID)
+	}
+
+	if model.Email.ValueString() != "jane.doe@example.com" || model.Name.ValueString() != "Jane Doe" {
+		t.Errorf("got email %s and name %s, want the planned values", model.Email, model.Name)
+	}
+
+	if !model.Subject.IsNull() || !model.Status.IsNull() || !model.AssignedRoles.IsNull() {
+		t.Errorf("got subject %s, status %s and roles %s, want unknown values nulled", model.Subject, model.Status, model.AssignedRoles)
+	}
+
+	if !model.DisableOnDestroy.ValueBool() {
+		t.Error("got disable_on_destroy false, want the planned true")
+	}
+}
@@ -0,0 +1,13 @@
+package resources
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// optionalString maps an optional API value to state, keeping empty values
+// null so that attributes left out of the configuration do not show a diff.
+func optionalString(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(value)
+}
@@ -0,0 +1,44 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+
+	qlikcloud "github.com/daniepett/qlik-cloud-client-go"
+)
+
+// WithContext returns a shallow copy of client whose requests carry ctx.
+//
+// The Qlik Cloud client builds its requests without a context, so this is
+// how the logger, deadlines and cancellation of the calling Terraform
+// operation reach the transports.
+func WithContext(ctx context.Context, client *qlikcloud.Client) *qlikcloud.Client {
+	c := *client
+
+	next := client.HTTPClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	c.HTTPClient = &http.Client{
+		Transport: &contextTransport{
+			ctx:  ctx,
+			next: next,
+		},
+		CheckRedirect: client.HTTPClient.CheckRedirect,
+		Jar:           client.HTTPClient.Jar,
+		Timeout:       client.HTTPClient.Timeout,
+	}
+
+	return &c
+}
+
+// contextTransport replaces the context of every request with its own.
+type contextTransport struct {
+	ctx  context.Context
+	next http.RoundTripper
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.next.RoundTrip(req.WithContext(t.ctx))
+}
@@ -0,0 +1,218 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// requestIDHeader is the response header holding the ID Qlik Cloud assigned
+// to the request, which support needs to trace a call.
+const requestIDHeader = "X-Request-Id"
+
+// redacted replaces secret values in logged bodies.
+const redacted = "***"
+
+// secretKeys are the (lower-cased) JSON keys and connection property names
+// whose values are never logged.
+var secretKeys = map[string]bool{
+	"password":                    true,
+	"qpassword":                   true,
+	"client_secret":               true,
+	"clientsecret":                true,
+	"access_token":                true,
+	"api_key":                     true,
+	"apikey":                      true,
+	"qconnectionsecret":           true,
+	"connectionsecretstring":      true,
+	"credentialsconnectionstring": true,
+}
+
+var (
+	// secretAssignments matches secrets embedded in connect statements such
+	// as "server=x;password=y".
+	secretAssignments = regexp.MustCompile(`(?i)\b((?:password|pwd)=)[^;"]*`)
+	// bearerTokens matches tokens in authorization values.
+	bearerTokens = regexp.MustCompile(`(?i)\b(bearer\s+)[A-Za-z0-9\-._~+/]+=*`)
+)
+
+// loggingTransport logs every request and response through tflog, using the
+// logger carried by the request context.
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+// NewLoggingTransport wraps next so that each request is logged at DEBUG with
+// its method, path, status, latency and Qlik request ID, and its request and
+// response bodies at TRACE. Secrets are masked before anything is logged.
+func NewLoggingTransport(next http.RoundTripper) http.RoundTripper {
+	return &loggingTransport{
+		next: next,
+	}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	fields := map[string]interface{}{
+		"method": req.Method,
+		"path":   req.URL.Path,
+	}
+
+	if req.URL.RawQuery != "" {
+		fields["query"] = req.URL.RawQuery
+	}
+
+	tflog.Debug(ctx, "Sending Qlik Cloud API request", fields)
+
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			b, _ := io.ReadAll(body)
+			body.Close()
+			tflog.Trace(ctx, "Qlik Cloud API request body", withField(fields, "body", redactBody(b)))
+		}
+	}
+
+	start := time.Now()
+	res, err := t.next.RoundTrip(req)
+	latency := time.Since(start)
+
+	if err != nil {
+		tflog.Debug(ctx, "Qlik Cloud API request failed", withFields(fields, map[string]interface{}{
+			"error":      redactString(err.Error()),
+			"latency_ms": latency.Milliseconds(),
+		}))
+		return nil, err
+	}
+
+	fields = withFields(fields, map[string]interface{}{
+		"status":     res.StatusCode,
+		"latency_ms": latency.Milliseconds(),
+		"request_id": res.Header.Get(requestIDHeader),
+	})
+
+	tflog.Debug(ctx, "Received Qlik Cloud API response", fields)
+
+	// Buffer the body so it can be logged and still be read by the client.
+	b, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(b))
+
+	tflog.Trace(ctx, "Qlik Cloud API response body", withField(fields, "body", redactBody(b)))
+
+	return res, nil
+}
+
+// withField returns a copy of fields with key set to value.
+func withField(fields map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	return withFields(fields, map[string]interface{}{key: value})
+}
+
+// withFields returns a copy of fields with extra merged in.
+func withFields(fields map[string]interface{}, extra map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(fields)+len(extra))
+	for k, v := range fields {
+		merged[k] = v
+	}
+	for k, v := range extra {
+		merged[k] = v
+	}
+	return merged
+}
+
+// redactBody masks the secrets in a request or response body. JSON bodies
+// are masked structurally; anything else falls back to pattern matching.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	v, ok := decodeJSON(string(body))
+	if !ok {
+		return redactString(string(body))
+	}
+
+	out, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return redacted
+	}
+
+	return string(out)
+}
+
+// redactValue masks the secrets in a decoded JSON value.
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		// Connection properties are sent as name/value pairs.
+		if name, ok := v["name"].(string); ok && secretKeys[strings.ToLower(name)] {
+			if _, ok := v["value"]; ok {
+				v["value"] = redacted
+			}
+		}
+
+		for key, value := range v {
+			if secretKeys[strings.ToLower(key)] {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactValue(value)
+		}
+
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactValue(value)
+		}
+
+		return v
+	case string:
+		// Some payloads, such as the properties sent to getConnectionString,
+		// are JSON documents encoded as strings.
+		if nested, ok := decodeJSON(v); ok {
+			out, err := json.Marshal(redactValue(nested))
+			if err != nil {
+				return redacted
+			}
+			return string(out)
+		}
+
+		return redactString(v)
+	default:
+		return v
+	}
+}
+
+// redactString masks connect statement passwords and bearer tokens.
+func redactString(s string) string {
+	s = secretAssignments.ReplaceAllString(s, "${1}"+redacted)
+	s = bearerTokens.ReplaceAllString(s, "${1}"+redacted)
+	return s
+}
+
+// decodeJSON decodes s if it holds a JSON object or array.
+func decodeJSON(s string) (interface{}, bool) {
+	trimmed := strings.TrimSpace(s)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return nil, false
+	}
+
+	d := json.NewDecoder(strings.NewReader(trimmed))
+	d.UseNumber()
+
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return nil, false
+	}
+
+	return v, true
+}
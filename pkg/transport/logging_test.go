@@ -0,0 +1,66 @@
+package transport
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRedactBody(t *testing.T) {
+	properties, _ := json.Marshal([]string{
+		`{"propertiesList":[{"name":"server","value":"acme.snowflakecomputing.com"}]}`,
+		"",
+		`[{"name":"password","value":"hunter2"}]`,
+	})
+
+	cases := map[string]struct {
+		body    string
+		secrets []string
+		keep    []string
+	}{
+		"token request": {
+			body:    `{"client_id":"abc","client_secret":"s3cr3t","grant_type":"client_credentials"}`,
+			secrets: []string{"s3cr3t"},
+			keep:    []string{"abc", "client_credentials"},
+		},
+		"token response": {
+			body:    `{"access_token":"eyJhbGciOi.payload.sig","token_type":"bearer"}`,
+			secrets: []string{"eyJhbGciOi.payload.sig"},
+		},
+		"data connection": {
+			body:    `{"qName":"snowflake","qPassword":"hunter2","qConnectStatement":"CUSTOM CONNECT TO \"provider=x;server=y;password=hunter2;\""}`,
+			secrets: []string{"hunter2"},
+			keep:    []string{"snowflake", "server=y"},
+		},
+		"connection string properties": {
+			body:    string(properties),
+			secrets: []string{"hunter2"},
+			keep:    []string{"acme.snowflakecomputing.com"},
+		},
+		"connection string response": {
+			body:    `{"connectionString":"server=y","credentialsConnectionString":"user=u;password=hunter2"}`,
+			secrets: []string{"hunter2"},
+			keep:    []string{"server=y"},
+		},
+		"not json": {
+			body:    `Authorization: Bearer abc.def-ghi`,
+			secrets: []string{"abc.def-ghi"},
+		},
+	}
+
+	for name, c := range cases {
+		got := redactBody([]byte(c.body))
+
+		for _, secret := range c.secrets {
+			if strings.Contains(got, secret) {
+				t.Errorf("%s: %q leaks %q", name, got, secret)
+			}
+		}
+
+		for _, keep := range c.keep {
+			if !strings.Contains(got, keep) {
+				t.Errorf("%s: %q is missing %q", name, got, keep)
+			}
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package transport
+
+import (
+	"io"
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// LimitOptions configures how many requests may be sent to the tenant.
+type LimitOptions struct {
+	// MaxConcurrentRequests caps the number of requests in flight. Zero
+	// means unlimited.
+	MaxConcurrentRequests int
+	// RequestsPerSecond is the sustained request budget. Zero means
+	// unlimited.
+	RequestsPerSecond float64
+}
+
+// limitTransport holds back requests until a concurrency slot is free and
+// the request budget allows them to be sent.
+type limitTransport struct {
+	next    http.RoundTripper
+	slots   chan struct{}
+	limiter *rate.Limiter
+}
+
+// NewLimitTransport wraps next so that every request sent through it shares
+// the same concurrency limit and request budget. A slot is held until the
+// response body is closed.
+func NewLimitTransport(next http.RoundTripper, options LimitOptions) http.RoundTripper {
+	t := &limitTransport{
+		next: next,
+	}
+
+	if options.MaxConcurrentRequests > 0 {
+		t.slots = make(chan struct{}, options.MaxConcurrentRequests)
+	}
+
+	if options.RequestsPerSecond > 0 {
+		// Allow a burst of one second worth of requests, but always at least
+		// one so that budgets below one request per second still work.
+		burst := int(options.RequestsPerSecond)
+		if burst < 1 {
+			burst = 1
+		}
+		t.limiter = rate.NewLimiter(rate.Limit(options.RequestsPerSecond), burst)
+	}
+
+	return t
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.limiter != nil {
+		if err := t.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if t.slots == nil {
+		return t.next.RoundTrip(req)
+	}
+
+	select {
+	case t.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		<-t.slots
+		return nil, err
+	}
+
+	res.Body = &releaseBody{
+		ReadCloser: res.Body,
+		release:    func() { <-t.slots },
+	}
+
+	return res, nil
+}
+
+// releaseBody frees a concurrency slot once the response has been consumed.
+type releaseBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -0,0 +1,174 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryOptions configures how failed requests are retried.
+type RetryOptions struct {
+	// MaxRetries is the number of times a request is retried after the
+	// first attempt. Zero disables retries.
+	MaxRetries int
+	// MinBackoff is the delay before the first retry. It doubles with every
+	// following attempt.
+	MinBackoff time.Duration
+	// MaxBackoff caps the computed delay between attempts. It does not
+	// shorten the delay asked for by a Retry-After header.
+	MaxBackoff time.Duration
+}
+
+// retryTransport retries requests that failed with a network error, were
+// rate limited (429) or hit a server error (5xx). Requests that are not
+// idempotent, such as creates, are only retried when the server cannot have
+// acted on them.
+type retryTransport struct {
+	next    http.RoundTripper
+	options RetryOptions
+}
+
+// NewRetryTransport wraps next so that transient failures are retried with
+// exponential backoff. A Retry-After header sent with the response takes
+// precedence over the computed delay and is waited in full. When it ends
+// after the deadline of the request, the request fails right away instead.
+func NewRetryTransport(next http.RoundTripper, options RetryOptions) http.RoundTripper {
+	return &retryTransport{
+		next:    next,
+		options: options,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		r := req
+		if attempt > 0 && req.Body != nil {
+			// The previous attempt consumed the body, so start from a fresh copy.
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r = req.Clone(ctx)
+			r.Body = body
+		}
+
+		res, err := t.next.RoundTrip(r)
+
+		if attempt >= t.options.MaxRetries || !shouldRetry(req, res, err) || ctx.Err() != nil {
+			return res, err
+		}
+
+		// Requests whose body cannot be replayed are only attempted once.
+		if req.Body != nil && req.GetBody == nil {
+			return res, err
+		}
+
+		wait, requested := t.backoff(attempt, res)
+
+		if res != nil {
+			// Drain the body so the connection can be reused.
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		if deadline, ok := ctx.Deadline(); ok && requested && time.Until(deadline) < wait {
+			return nil, fmt.Errorf("the server asked to retry the request after %s, which is past its deadline: %w", wait, context.DeadlineExceeded)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// idempotentMethods are the methods whose requests can be sent again without
+// changing the outcome when the first attempt did reach the server.
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+}
+
+// shouldRetry reports whether the outcome of an attempt is worth retrying.
+// A POST or PATCH that failed with a server error or after the connection
+// was made may already have been applied, so it is only retried when it was
+// rate limited or could not connect at all.
+func shouldRetry(req *http.Request, res *http.Response, err error) bool {
+	if err != nil {
+		return idempotentMethods[req.Method] || isDialError(err)
+	}
+
+	if res.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	return idempotentMethods[req.Method] && res.StatusCode >= 500 && res.StatusCode != http.StatusNotImplemented
+}
+
+// isDialError reports whether err happened while connecting to the server,
+// before any of the request was sent.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// backoff returns the delay before the next attempt, and whether it is the
+// one the server asked for with a Retry-After header.
+func (t *retryTransport) backoff(attempt int, res *http.Response) (time.Duration, bool) {
+	if res != nil {
+		if wait, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+			return wait, true
+		}
+	}
+
+	wait := time.Duration(float64(t.options.MinBackoff) * math.Pow(2, float64(attempt)))
+	if wait <= 0 || wait > t.options.MaxBackoff {
+		wait = t.options.MaxBackoff
+	}
+
+	// Spread out retries of requests that failed together, which is what
+	// happens when many resources are applied in parallel.
+	jitter := time.Duration(rand.Int63n(int64(wait)/2 + 1))
+
+	return wait/2 + jitter, false
+}
+
+// retryAfter parses a Retry-After header, which holds either a number of
+// seconds or an HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,259 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	var attempts int
+	var bodies []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		switch attempts {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: NewRetryTransport(http.DefaultTransport, RetryOptions{
+			MaxRetries: 3,
+			MinBackoff: time.Millisecond,
+			MaxBackoff: 5 * time.Millisecond,
+		}),
+	}
+
+	req, err := http.NewRequest(http.MethodPut, server.URL, strings.NewReader(`{"name":"space"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated {
+		t.Errorf("got status %d, want %d", res.StatusCode, http.StatusCreated)
+	}
+
+	if attempts != 3 {
+		t.Errorf("got %d attempts, want 3", attempts)
+	}
+
+	for i, body := range bodies {
+		if body != `{"name":"space"}` {
+			t.Errorf("attempt %d sent body %q", i+1, body)
+		}
+	}
+}
+
+func TestRetryTransportGivesUp(t *testing.T) {
+	var attempts int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: NewRetryTransport(http.DefaultTransport, RetryOptions{
+			MaxRetries: 2,
+			MinBackoff: time.Millisecond,
+			MaxBackoff: time.Millisecond,
+		}),
+	}
+
+	res, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusBadGateway {
+		t.Errorf("got status %d, want %d", res.StatusCode, http.StatusBadGateway)
+	}
+
+	if attempts != 3 {
+		t.Errorf("got %d attempts, want 3", attempts)
+	}
+}
+
+func TestRetryTransportDoesNotRetryClientErrors(t *testing.T) {
+	var attempts int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: NewRetryTransport(http.DefaultTransport, RetryOptions{
+			MaxRetries: 2,
+			MinBackoff: time.Millisecond,
+			MaxBackoff: time.Millisecond,
+		}),
+	}
+
+	res, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res.Body.Close()
+
+	if attempts != 1 {
+		t.Errorf("got %d attempts, want 1", attempts)
+	}
+}
+
+func TestRetryTransportDoesNotRetryPostAfterServerError(t *testing.T) {
+	var attempts int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: NewRetryTransport(http.DefaultTransport, RetryOptions{
+			MaxRetries: 2,
+			MinBackoff: time.Millisecond,
+			MaxBackoff: time.Millisecond,
+		}),
+	}
+
+	res, err := client.Post(server.URL, "application/json", strings.NewReader(`{"name":"space"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusBadGateway {
+		t.Errorf("got status %d, want %d", res.StatusCode, http.StatusBadGateway)
+	}
+
+	if attempts != 1 {
+		t.Errorf("got %d attempts, want 1", attempts)
+	}
+}
+
+func TestRetryTransportRetriesPostWhenRateLimited(t *testing.T) {
+	var attempts int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: NewRetryTransport(http.DefaultTransport, RetryOptions{
+			MaxRetries: 2,
+			MinBackoff: time.Millisecond,
+			MaxBackoff: time.Millisecond,
+		}),
+	}
+
+	res, err := client.Post(server.URL, "application/json", strings.NewReader(`{"name":"space"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated || attempts != 2 {
+		t.Errorf("got status %d after %d attempts, want %d after 2", res.StatusCode, attempts, http.StatusCreated)
+	}
+}
+
+func TestBackoffHonorsRetryAfter(t *testing.T) {
+	transport := &retryTransport{options: RetryOptions{
+		MinBackoff: time.Millisecond,
+		MaxBackoff: time.Second,
+	}}
+
+	res := &http.Response{Header: http.Header{"Retry-After": []string{"3600"}}}
+	if wait, requested := transport.backoff(0, res); wait != time.Hour || !requested {
+		t.Errorf("got backoff %s, %t, want %s, true", wait, requested, time.Hour)
+	}
+
+	if wait, requested := transport.backoff(10, &http.Response{}); wait > time.Second || requested {
+		t.Errorf("got backoff %s, %t, want at most %s, false", wait, requested, time.Second)
+	}
+}
+
+func TestRetryTransportFailsWhenRetryAfterIsPastDeadline(t *testing.T) {
+	var attempts int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: NewRetryTransport(http.DefaultTransport, RetryOptions{
+			MaxRetries: 3,
+			MinBackoff: time.Millisecond,
+			MaxBackoff: 5 * time.Millisecond,
+		}),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	_, err = client.Do(req)
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "retry the request after 1h0m0s") {
+		t.Fatalf("got error %v, want a deadline exceeded error naming the requested delay", err)
+	}
+
+	if attempts != 1 || time.Since(start) > 10*time.Second {
+		t.Errorf("got %d attempts in %s, want 1 without waiting", attempts, time.Since(start))
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	if wait, ok := retryAfter("3"); !ok || wait != 3*time.Second {
+		t.Errorf("retryAfter(\"3\") = %s, %t", wait, ok)
+	}
+
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if wait, ok := retryAfter(date); !ok || wait <= 0 || wait > time.Minute {
+		t.Errorf("retryAfter(%q) = %s, %t", date, wait, ok)
+	}
+
+	if _, ok := retryAfter("soon"); ok {
+		t.Error("retryAfter(\"soon\") should not parse")
+	}
+}